@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dhruvmanila/pie/internal/config"
+	"github.com/dhruvmanila/pie/pythonfinder"
+)
+
+var pythonAliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage the names given to Python executables",
+	Long: `Manage the names given to Python executables, which can be used in place of
+a Python version, like 'pie create --python corp-3.11'.`,
+	Args: cobra.NoArgs,
+}
+
+var pythonAliasAddCmd = &cobra.Command{
+	Use:   "add <name> <path>",
+	Short: "Add an alias for the given Python executable",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		if err := config.ValidateAliasName(name); err != nil {
+			log.Fatal(red.Sprintf("✘ %s", err))
+		}
+		path, err := filepath.Abs(args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		finder := pythonfinder.New(finderOptions()...)
+		v, err := finder.Find(cmd.Context(), path)
+		if err != nil {
+			if errors.Is(err, pythonfinder.ErrVersionNotFound) {
+				log.Fatal(red.Sprintf("✘ Python executable %s does not exist!", path))
+			}
+			log.Fatal(red.Sprintf("✘ %s", err))
+		}
+
+		aliases := loadAliases()
+		aliases[name] = path
+		if err = aliases.Save(aliasesPath()); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s %s\n",
+			green.Sprintf("✔ Added alias %s for %s", name, path),
+			yellowBold.Sprintf("(%s)", versionLabel(v)),
+		)
+	},
+}
+
+var pythonAliasRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Short:   "Remove the given alias",
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		name := args[0]
+		aliases := loadAliases()
+		if _, ok := aliases[name]; !ok {
+			log.Fatal(red.Sprintf("✘ Alias %s does not exist!", name))
+		}
+		delete(aliases, name)
+		if err := aliases.Save(aliasesPath()); err != nil {
+			log.Fatal(err)
+		}
+		green.Printf("✔ Removed alias %s\n", name)
+	},
+}
+
+var pythonAliasListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List all the aliases",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		aliases := loadAliases()
+		names := make([]string, 0, len(aliases))
+		for name := range aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("%s %s\n", bold.Sprint(name), faint.Sprint(aliases[name]))
+		}
+	},
+}
+
+func init() {
+	pythonAliasCmd.AddCommand(pythonAliasAddCmd, pythonAliasRemoveCmd, pythonAliasListCmd)
+	pythonCmd.AddCommand(pythonAliasCmd)
+}
@@ -6,6 +6,7 @@ var (
 	bold       = color.New(color.Bold)
 	yellowBold = color.New(color.Bold, color.FgYellow)
 	green      = color.New(color.FgGreen)
+	yellow     = color.New(color.FgYellow)
 	red        = color.New(color.FgRed)
 	faint      = color.New(color.Faint)
 )
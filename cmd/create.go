@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -15,7 +16,7 @@ import (
 
 	"github.com/dhruvmanila/pie/internal/pathutil"
 	"github.com/dhruvmanila/pie/internal/project"
-	"github.com/dhruvmanila/pie/internal/pythonfinder"
+	"github.com/dhruvmanila/pie/pythonfinder"
 )
 
 // pythonVersion is the Python version to use for creating the virtual environment.
@@ -27,10 +28,30 @@ var createCmd = &cobra.Command{
 	Long: `Create a virtual environment for the current directory.
 
 The environment will be created using the builtin 'venv' module. If the
-'--python' flag is not specified, the default Python version will be used.
+'--python' flag is not specified, the Python version declared by the project
+is used, which is looked up in the following order:
+  1. The '.python-version' file, where the first available version is used.
+  2. The 'requires-python' key in the '[project]' table of 'pyproject.toml'.
+Otherwise, the default Python version will be used.
+
+The '--python' flag accepts either a version like '3.11' or '3.11.2', or a
+PEP 440 version specifier like '>=3.10,<3.13' or '~=3.11'. If multiple Python
+versions match, the newest one is used unless '--prefer oldest' is given. The
+pre-releases, like '3.14.0b3', are only used when requested exactly, or with
+the '--allow-prereleases' flag if no final release matches the version.
+
+The version can be prefixed with a Python implementation to only consider the
+Python versions of that implementation, like 'pypy3.10', 'cpython@3.12' or
+just 'graalpy'. Without one, CPython is preferred and the other implementations
+are only used if no CPython version matches. The free-threaded builds are only used when the version has
+the 't' suffix, like '3.13t'.
+
+The '--python' flag also accepts the path to a Python executable, like
+'/opt/python-3.11/bin/python3', or the name of an alias defined using the
+'pie python alias add' command.
 `,
 	Args: cobra.NoArgs,
-	Run: func(_ *cobra.Command, _ []string) {
+	Run: func(cmd *cobra.Command, _ []string) {
 		bold.Println("==> Creating a virtualenv for this project...")
 
 		p, err := project.NewFromWd()
@@ -42,10 +63,15 @@ The environment will be created using the builtin 'venv' module. If the
 			log.Fatal(red.Sprintf("✘ Virtualenv already exists for this project: %s", p.Name))
 		}
 
-		if err = createVenv(p); err != nil {
-			if errors.Is(err, pythonfinder.ErrVersionNotFound) {
-				if pythonVersion != "" {
-					log.Fatal(red.Sprintf("✘ Python version %s does not exist!", pythonVersion))
+		v, err := createVenv(cmd.Context(), p)
+		if err != nil {
+			var notFound *versionNotFoundError
+			if errors.As(err, &notFound) {
+				if len(notFound.rejected) > 0 {
+					fatalRejected(notFound.requested, notFound.rejected)
+				}
+				if notFound.requested != "" {
+					log.Fatal(notFoundMessage(cmd.Context(), notFound.requested, notFound.version))
 				} else {
 					log.Fatal(red.Sprintf("✘ No Python version found!"))
 				}
@@ -57,6 +83,9 @@ The environment will be created using the builtin 'venv' module. If the
 		if err = p.WriteProjectFile(); err != nil {
 			log.Fatal(err)
 		}
+		if err = p.WriteArchFile(v.Architecture()); err != nil {
+			log.Fatal(err)
+		}
 
 		green.Println("✔ Successfully created virtual environment!")
 		fmt.Printf("Virtualenv location: %s\n", green.Sprint(p.VenvDir))
@@ -69,12 +98,144 @@ func init() {
 		&pythonVersion, "python", "", `specify which version of Python to use for
 creating the virtualenv`,
 	)
+	createCmd.Flags().BoolVar(&refresh, "refresh", false, "ignore the cached Python versions and find them again")
+	createCmd.Flags().StringVar(
+		&prefer, "prefer", "", `choose the "newest" (default) or "oldest" Python version
+when multiple versions match`,
+	)
+	createCmd.Flags().StringSliceVar(&providers, "providers", nil, providersUsage)
+	createCmd.Flags().BoolVar(&virtualenvBase, "venv-base", false, virtualenvBaseUsage)
+	createCmd.Flags().BoolVar(&allowPrereleases, "allow-prereleases", false, allowPrereleasesUsage)
+	createCmd.Flags().StringVar(&arch, "arch", "", archUsage)
+	createCmd.Flags().BoolVar(&explain, "explain", false, explainUsage)
+}
+
+// versionNotFoundError is returned when none of the requested Python
+// versions exist.
+type versionNotFoundError struct {
+	// requested describes the requested Python version and its source. This
+	// is empty if no version was requested.
+	requested string
+
+	// version is the first requested Python version, which is used to
+	// suggest the closest available ones.
+	version string
+
+	// rejected describes the Python executables which match the requested
+	// versions but cannot create a virtual environment.
+	rejected []pythonfinder.Diagnostic
+}
+
+func (e *versionNotFoundError) Error() string {
+	if e.requested == "" {
+		return pythonfinder.ErrVersionNotFound.Error()
+	}
+	return fmt.Sprintf("%s: %s", pythonfinder.ErrVersionNotFound, e.requested)
+}
+
+func (e *versionNotFoundError) Unwrap() error {
+	return pythonfinder.ErrVersionNotFound
+}
+
+// findPython finds the Python executable to create the virtual environment
+// for the given project. The requested version is resolved from, in order,
+// the '--python' flag, the versions declared by the project and the default
+// Python version. The source of the request is printed.
+func findPython(ctx context.Context, p *project.Project) (*pythonfinder.PythonExecutable, error) {
+	finder := newVenvFinder()
+	aliases := loadAliases()
+
+	// Each search replaces the diagnostics of the finder, so they are
+	// collected after each one, without the ones found again.
+	var diagnostics []pythonfinder.Diagnostic
+	seen := make(map[string]bool)
+	defer func() {
+		printDiagnostics(diagnostics)
+	}()
+	find := func(version string) (*pythonfinder.PythonExecutable, error) {
+		v, err := finder.Find(ctx, version)
+		for _, d := range finder.Diagnostics() {
+			if !seen[d.String()] {
+				seen[d.String()] = true
+				diagnostics = append(diagnostics, d)
+			}
+		}
+		return v, err
+	}
+
+	var versions []string
+	var source string
+	if pythonVersion != "" {
+		versions, source = []string{pythonVersion}, "--python"
+	} else {
+		request, err := p.PythonRequest()
+		if err != nil {
+			return nil, err
+		}
+		if request != nil {
+			versions, source = request.Versions, request.Source
+		}
+	}
+
+	if len(versions) == 0 {
+		fmt.Println("No Python version requested, using the default...")
+		v, err := find("")
+		if errors.Is(err, pythonfinder.ErrVersionNotFound) {
+			return nil, &versionNotFoundError{rejected: rejectedBy(err)}
+		}
+		return v, err
+	}
+
+	fmt.Printf("Requested Python version %s %s\n",
+		yellowBold.Sprint(strings.Join(versions, ", ")),
+		faint.Sprintf("(from %s)", source),
+	)
+
+	var rejected []pythonfinder.Diagnostic
+	seenRejected := make(map[string]bool)
+	for _, version := range versions {
+		v, err := find(resolveAlias(aliases, version))
+		if err == nil {
+			return v, nil
+		}
+		for _, d := range rejectedBy(err) {
+			// The same Python executable can match multiple versions.
+			if !seenRejected[d.String()] {
+				seenRejected[d.String()] = true
+				rejected = append(rejected, d)
+			}
+		}
+		// A version declared by the project might not be understood by pie,
+		// like "miniconda3-latest" in '.python-version', in which case the
+		// next one is tried.
+		if !errors.Is(err, pythonfinder.ErrVersionNotFound) && source == "--python" {
+			return nil, err
+		}
+	}
+
+	return nil, &versionNotFoundError{
+		requested: fmt.Sprintf("%s (from %s)", strings.Join(versions, ", "), source),
+		version:   versions[0],
+		rejected:  rejected,
+	}
+}
+
+// rejectedBy returns the Python executables rejected by the finder filters if
+// the given error is a RejectedError, and nil otherwise.
+func rejectedBy(err error) []pythonfinder.Diagnostic {
+	var rejectedErr *pythonfinder.RejectedError
+	if errors.As(err, &rejectedErr) {
+		return rejectedErr.Rejected
+	}
+	return nil
 }
 
-func createVenv(p *project.Project) error {
-	v, err := pythonfinder.New().Find(pythonVersion)
+// createVenv creates the virtual environment for the given project and
+// returns the Python executable it was created from.
+func createVenv(ctx context.Context, p *project.Project) (*pythonfinder.PythonExecutable, error) {
+	v, err := findPython(ctx, p)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	fmt.Printf("Using %s %s to create virtualenv...\n",
@@ -91,7 +252,7 @@ func createVenv(p *project.Project) error {
 	cmd.Stderr = &stderr
 
 	if err = cmd.Start(); err != nil {
-		return commandError(err, cmd, stderr)
+		return nil, commandError(err, cmd, stderr)
 	}
 
 	// stop channel is used to signal that the command has finished and
@@ -132,10 +293,10 @@ func createVenv(p *project.Project) error {
 
 	// There was no signal received, so we can safely check the error.
 	if err != nil {
-		return commandError(err, cmd, stderr)
+		return nil, commandError(err, cmd, stderr)
 	}
 
-	return nil
+	return v, nil
 }
 
 // commandError returns a formatted error message on command failure.
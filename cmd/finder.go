@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dhruvmanila/pie/internal/config"
+	"github.com/dhruvmanila/pie/internal/xdg"
+	"github.com/dhruvmanila/pie/pythonfinder"
+)
+
+// providersEnvVar is the environment variable used to configure the enabled
+// providers, as a comma-separated list, overriding the config file.
+const providersEnvVar = "PIE_PYTHON_PROVIDERS"
+
+var (
+	// refresh is a flag to ignore the cached information about the Python
+	// executables and probe all of them again.
+	refresh bool
+
+	// prefer is a flag to choose either the "newest" or the "oldest" Python
+	// version when multiple versions match the requested version.
+	prefer string
+
+	// providers is a flag to configure the enabled providers in the order
+	// they're consulted, overriding the environment variable and the config
+	// file.
+	providers []string
+
+	// virtualenvBase is a flag to consider the base Python executable of the
+	// virtual environments in PATH instead of skipping them.
+	virtualenvBase bool
+
+	// allowPrereleases is a flag to use the pre-releases if no final release
+	// matches the requested version.
+	allowPrereleases bool
+
+	// arch is a flag to only consider the Python executables built for the
+	// given machine architecture.
+	arch string
+
+	// explain is a flag to trace the decisions made while finding the
+	// Python version, to explain why it was found or not.
+	explain bool
+)
+
+// loadConfig returns the user configuration from the config file.
+func loadConfig() *config.Config {
+	cfg, err := config.Load(filepath.Join(xdg.ConfigDir, "config.toml"))
+	if err != nil {
+		log.Fatal(red.Sprintf("✘ Invalid config: %s", err))
+	}
+	return cfg
+}
+
+// aliasesPath returns the path to the file storing the Python aliases.
+func aliasesPath() string {
+	return filepath.Join(xdg.ConfigDir, "aliases.toml")
+}
+
+// loadAliases returns the Python aliases defined by the user.
+func loadAliases() config.Aliases {
+	aliases, err := config.LoadAliases(aliasesPath())
+	if err != nil {
+		log.Fatal(red.Sprintf("✘ Invalid aliases: %s", err))
+	}
+	return aliases
+}
+
+// resolveAlias returns the path of the Python executable if the given
+// version is an alias, and the version as is otherwise. The aliases with an
+// invalid name, e.g., added to the file by hand, are ignored so that they
+// cannot override a version request like "pypy".
+func resolveAlias(aliases config.Aliases, version string) string {
+	if path, ok := aliases[version]; ok && config.ValidateAliasName(version) == nil {
+		return path
+	}
+	return version
+}
+
+// finderOptions returns the options for the Python finder as per the
+// command-line flags, the environment variables and the config file, in
+// that order of precedence.
+func finderOptions() []pythonfinder.Option {
+	cfg := loadConfig()
+
+	if prefer == "" {
+		prefer = cfg.Python.Prefer
+	}
+	if prefer == "" {
+		prefer = pythonfinder.PreferNewest.String()
+	}
+	preference, err := pythonfinder.ParsePreference(prefer)
+	if err != nil {
+		log.Fatal(red.Sprintf("✘ %s", err))
+	}
+
+	providerList := providers
+	if providerList == nil {
+		if value, ok := os.LookupEnv(providersEnvVar); ok {
+			providerList = strings.Split(value, ",")
+		} else {
+			providerList = cfg.Python.Providers
+		}
+	}
+	providerNames, err := pythonfinder.ParseProviders(providerList)
+	if err != nil {
+		log.Fatal(red.Sprintf("✘ %s", err))
+	}
+
+	opts := []pythonfinder.Option{
+		pythonfinder.WithCache(filepath.Join(xdg.CacheDir, "pythons.json")),
+		pythonfinder.WithRefresh(refresh),
+		pythonfinder.WithPreference(preference),
+		pythonfinder.WithProviders(providerNames),
+		pythonfinder.WithVirtualenvBase(virtualenvBase),
+		pythonfinder.WithSearchPaths(cfg.Python.SearchPaths),
+		pythonfinder.WithPrereleases(allowPrereleases || cfg.Python.AllowPrereleases),
+	}
+	if arch != "" {
+		opts = append(opts, pythonfinder.WithFilter(pythonfinder.ArchFilter(arch)))
+	}
+	if explain {
+		opts = append(opts, pythonfinder.WithTracer(printTrace))
+	}
+	return opts
+}
+
+// newVenvFinder returns the Python finder used to create a virtual
+// environment, which skips the Python executables that cannot create one.
+// The given options are applied after the ones from finderOptions.
+func newVenvFinder(extra ...pythonfinder.Option) *pythonfinder.Finder {
+	opts := append(finderOptions(), pythonfinder.WithFilter(pythonfinder.CanCreateVirtualenv))
+	return pythonfinder.New(append(opts, extra...)...)
+}
+
+// fatalRejected exits with the reasons why the Python executables matching
+// the requested version, which is empty for the default version, were
+// rejected, e.g., as they cannot create a virtual environment.
+func fatalRejected(requested string, rejected []pythonfinder.Diagnostic) {
+	var b strings.Builder
+	if requested == "" {
+		b.WriteString(red.Sprint("✘ No usable Python version found:"))
+	} else {
+		b.WriteString(red.Sprintf("✘ Python version %s was found but cannot be used:", requested))
+	}
+	missingModule := false
+	for _, d := range rejected {
+		fmt.Fprintf(&b, "\n  %s", d)
+		if errors.Is(d.Err, pythonfinder.ErrNoVenv) || errors.Is(d.Err, pythonfinder.ErrNoEnsurepip) {
+			missingModule = true
+		}
+	}
+	if missingModule {
+		b.WriteString("\nOn Debian or Ubuntu, install the 'python3-venv' package for the system Python,")
+		b.WriteString("\nor request another Python version.")
+	}
+	log.Fatal(b.String())
+}
+
+// printDiagnostics prints the failures which were skipped while finding the
+// Python versions as warnings to stderr.
+func printDiagnostics(diagnostics []pythonfinder.Diagnostic) {
+	for _, d := range diagnostics {
+		fmt.Fprintln(os.Stderr, yellow.Sprintf("⚠ Skipped %s", d))
+	}
+}
+
+// printTrace prints the given finder event to stderr, so that it does not
+// interfere with the output of the command.
+func printTrace(event pythonfinder.TraceEvent) {
+	var line string
+	switch event.Kind {
+	case pythonfinder.TraceProvider:
+		line = bold.Sprintf("→ Consulting the %s provider", event.Provider)
+	case pythonfinder.TraceCandidate:
+		line = faint.Sprintf("  found %s", event.Path)
+	case pythonfinder.TraceProbed:
+		line = fmt.Sprintf("  probed %s %s",
+			event.Path, yellowBold.Sprint(versionLabel(event.PythonExecutable)),
+		)
+	case pythonfinder.TraceRejected:
+		line = fmt.Sprintf("  %s %s", red.Sprintf("✘ rejected %s:", event.Path), event.Reason)
+	case pythonfinder.TraceMatched:
+		line = fmt.Sprintf("  matched %s %s",
+			event.Path, yellowBold.Sprint(versionLabel(event.PythonExecutable)),
+		)
+	case pythonfinder.TraceSelected:
+		if event.Provider == "" {
+			line = green.Sprintf("✔ Selected %s (%s)", event.Path, versionLabel(event.PythonExecutable))
+		} else {
+			line = green.Sprintf("✔ Selected %s (%s) from the %s provider",
+				event.Path, versionLabel(event.PythonExecutable), event.Provider,
+			)
+		}
+	}
+	fmt.Fprintln(os.Stderr, line)
+}
+
+// providersUsage is the usage message for the '--providers' flag.
+const providersUsage = `comma-separated list of providers to consult in order,
+where a "-" prefix disables a provider`
+
+// virtualenvBaseUsage is the usage message for the '--venv-base' flag.
+const virtualenvBaseUsage = `consider the base Python of the virtual environment
+executables instead of skipping them`
+
+// allowPrereleasesUsage is the usage message for the '--allow-prereleases' flag.
+const allowPrereleasesUsage = `use a pre-release, like 3.14.0b3 for 3.14, if no final
+release matches`
+
+// archUsage is the usage message for the '--arch' flag.
+const archUsage = `only consider the Python versions built for the given
+architecture, like "x86_64", "aarch64" or "x86" for 32-bit`
+
+// explainUsage is the usage message for the '--explain' flag.
+const explainUsage = "explain how the Python version was found or why it was not"
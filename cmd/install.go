@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dhruvmanila/pie/internal/toolchain"
+	"github.com/dhruvmanila/pie/internal/venv"
+	"github.com/dhruvmanila/pie/pythonfinder"
+)
+
+// from is the archive, or the directory containing the archives, to install
+// the Python version from.
+var from string
+
+var pythonInstallCmd = &cobra.Command{
+	Use:   "install <version> --from <dir-or-archive>",
+	Short: "Install a Python version from a python-build-standalone archive",
+	Long: `Install a Python version from a local python-build-standalone archive.
+
+The '--from' flag is either the path to an 'install_only' archive, like
+'cpython-3.12.3+20240415-x86_64-unknown-linux-gnu-install_only.tar.gz', or to a
+directory containing them, in which case the archive for the newest Python
+version matching the given version and built for the current platform is used.
+
+The checksum of the archive is verified against the one in the '<archive>.sha256'
+file next to it, or in the 'SHA256SUMS' file in the same directory.
+
+The installed Python versions are found by the 'pie' provider, so they can be
+used to create a virtualenv like any other Python version.
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		archive, err := toolchain.FindArchive(from, args[0])
+		if err != nil {
+			log.Fatal(red.Sprintf("✘ %s", err))
+		}
+
+		fmt.Printf("Verifying %s...\n", faint.Sprint(archive.Path))
+		if err = archive.Verify(); err != nil {
+			if errors.Is(err, toolchain.ErrNoChecksum) {
+				log.Fatal(red.Sprintf(
+					"✘ %s\nPut the checksum in the '%s.sha256' file, or in the 'SHA256SUMS' file next to it.",
+					err, archive.Path,
+				))
+			}
+			log.Fatal(red.Sprintf("✘ %s", err))
+		}
+
+		fmt.Printf("Installing Python %s...\n", yellowBold.Sprint(archive.Version))
+		t, err := archive.Install()
+		if err != nil {
+			log.Fatal(red.Sprintf("✘ %s", err))
+		}
+
+		// Ensure that the installed Python actually runs on this system, like
+		// an archive built for a newer glibc.
+		finder := pythonfinder.New()
+		if _, err = finder.Find(cmd.Context(), t.Executable()); err != nil {
+			os.RemoveAll(t.Path)
+			log.Fatal(red.Sprintf("✘ %s", err))
+		}
+
+		green.Printf("✔ Successfully installed Python %s\n", archive.Version)
+		fmt.Printf("Python location: %s\n", green.Sprint(t.Executable()))
+	},
+}
+
+var pythonUninstallCmd = &cobra.Command{
+	Use:   "uninstall <version-or-name>",
+	Short: "Uninstall a Python version installed by pie",
+	Long: `Uninstall a Python version installed using the 'pie python install' command.
+
+The Python version can be given either as a version like '3.12' or '3.12.3', or
+as the name of the installation directory. It cannot be uninstalled while a
+virtualenv is still using it.
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		toolchains, err := toolchain.Match(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		switch len(toolchains) {
+		case 0:
+			log.Fatal(red.Sprintf("✘ Python version %s is not installed!", args[0]))
+		case 1:
+		default:
+			names := make([]string, len(toolchains))
+			for i, t := range toolchains {
+				names[i] = t.Name
+			}
+			log.Fatal(red.Sprintf("✘ Multiple Python versions match %s: %s", args[0], strings.Join(names, ", ")))
+		}
+		t := toolchains[0]
+
+		venvNames, err := referencingVenvs(t)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(venvNames) > 0 {
+			log.Fatal(red.Sprintf(
+				"✘ Python %s is used by the following virtualenvs, remove them first:\n  %s",
+				t.Version, strings.Join(venvNames, "\n  "),
+			))
+		}
+
+		fmt.Printf("Removing Python %s (%s)...\n", yellowBold.Sprint(t.Version), green.Sprint(t.Path))
+		if err = os.RemoveAll(t.Path); err != nil {
+			log.Fatal(err)
+		}
+		green.Println("✔ Successfully uninstalled Python!")
+	},
+}
+
+// referencingVenvs returns the names of the virtual environments created from
+// the Python executable of the given toolchain.
+func referencingVenvs(t *toolchain.Toolchain) ([]string, error) {
+	venvNames, err := venv.Names()
+	if err != nil {
+		return nil, err
+	}
+
+	var referencing []string
+	for _, venvName := range venvNames {
+		home, err := venv.PythonHome(venvName)
+		if err != nil {
+			// The environment is broken, so it cannot be using the
+			// toolchain anyway.
+			continue
+		}
+		if t.Contains(home) {
+			referencing = append(referencing, venvName)
+		}
+	}
+	return referencing, nil
+}
+
+func init() {
+	pythonInstallCmd.Flags().StringVar(&from, "from", "", "python-build-standalone archive, or directory containing them")
+	_ = pythonInstallCmd.MarkFlagRequired("from")
+	pythonCmd.AddCommand(pythonInstallCmd, pythonUninstallCmd)
+}
@@ -1,21 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
-	"github.com/dhruvmanila/pie/internal/pythonfinder"
 	"github.com/dhruvmanila/pie/internal/venv"
 	"github.com/dhruvmanila/pie/internal/xdg"
+	"github.com/dhruvmanila/pie/pythonfinder"
 )
 
 var (
-	// verbose is a flag used to output additional environment information.
+	// verbose is a flag used to output additional environment information,
+	// or additional information about the Python versions with '--execs'.
 	verbose bool
 
 	// execs is a flag used to output all the available Python versions and
@@ -28,30 +31,123 @@ var listCmd = &cobra.Command{
 	Short:   "List out all the managed virtualenvs",
 	Aliases: []string{"ls"},
 	Args:    cobra.NoArgs,
-	Run: func(_ *cobra.Command, _ []string) {
+	Run: func(cmd *cobra.Command, _ []string) {
 		if execs {
-			printPythonVersions()
+			printPythonVersions(cmd.Context())
 		} else {
 			printVenvs()
 		}
 	},
 }
 
-func printPythonVersions() {
-	versions, err := pythonfinder.New().FindAll()
+func printPythonVersions(ctx context.Context) {
+	finder := pythonfinder.New(finderOptions()...)
+	installations, err := finder.FindAll(ctx)
 	if err != nil {
+		printDiagnostics(finder.Diagnostics())
 		if errors.Is(err, pythonfinder.ErrVersionNotFound) {
+			if arch != "" {
+				log.Fatal(red.Sprintf("✘ No Python version found on the system for the %s architecture", pythonfinder.NormalizeArch(arch)))
+			}
 			log.Fatal(red.Sprint("✘ No Python version found on the system"))
 		}
 		log.Fatal(err)
 	}
+
+	// Each installation is listed in the section of the first provider which
+	// found it, and the sections are in the order of the providers.
+	var sections []string
+	byProvider := make(map[string][]*pythonfinder.Installation)
+	for _, installation := range installations {
+		provider := installation.Providers[0]
+		if _, ok := byProvider[provider]; !ok {
+			sections = append(sections, provider)
+		}
+		byProvider[provider] = append(byProvider[provider], installation)
+	}
+
 	bold.Println("Found Python versions:")
-	for _, v := range versions {
-		fmt.Printf("  %s %s\n",
-			yellowBold.Sprint(v.Version),
-			faint.Sprintf("(%s)", v.Path),
+	for _, provider := range sections {
+		fmt.Printf("  %s\n", bold.Sprint(provider))
+		for _, v := range byProvider[provider] {
+			var tags string
+			if v.Version.IsPreRelease() {
+				tags += yellow.Sprint(" [pre-release]")
+			}
+			if v.FreeThreaded {
+				tags += green.Sprint(" [free-threaded]")
+			}
+			if len(v.Providers) > 1 {
+				tags += faint.Sprintf(" [also found by %s]", strings.Join(v.Providers[1:], ", "))
+			}
+			fmt.Printf("    %s %s%s\n",
+				yellowBold.Sprint(versionLabel(v.PythonExecutable)),
+				faint.Sprintf("(%s)", v.Path),
+				tags,
+			)
+			for _, alias := range v.Aliases {
+				if alias != v.Path {
+					fmt.Printf("      %s\n", faint.Sprintf("↳ %s", alias))
+				}
+			}
+			if verbose {
+				printPythonDetails(v.PythonExecutable)
+			}
+		}
+	}
+	printDiagnostics(finder.Diagnostics())
+}
+
+// versionLabel returns the label used to display the version of the given
+// Python executable, which includes the implementation unless it's CPython.
+// The version of a free-threaded build has the "t" suffix, the same as the
+// one used to request it.
+func versionLabel(v *pythonfinder.PythonExecutable) string {
+	label := v.Version.String()
+	if v.FreeThreaded {
+		label += "t"
+	}
+	if v.Implementation == "" || v.Implementation == "cpython" {
+		return label
+	}
+	return fmt.Sprintf("%s %s", v.Implementation, label)
+}
+
+// printPythonDetails prints the introspected information about the given
+// Python executable, and its registration in the Windows registry if any, one
+// detail per line.
+func printPythonDetails(v *pythonfinder.PythonExecutable) {
+	type detail struct {
+		name  string
+		value string
+	}
+	details := []detail{
+		{"implementation", v.Implementation},
+		{"architecture", fmt.Sprintf("%s (%d-bit)", v.Architecture(), v.PointerSize)},
+		{"base prefix", v.BasePrefix},
+		{"abi flags", fmt.Sprintf("%q", v.ABIFlags)},
+		{"free-threaded", yesNo(v.FreeThreaded)},
+		{"venv", yesNo(v.HasVenv)},
+		{"ensurepip", yesNo(v.HasEnsurepip)},
+	}
+	if v.Registry != nil {
+		details = append(details,
+			detail{"company", v.Registry.Company},
+			detail{"tag", v.Registry.Tag},
+			detail{"install path", v.Registry.InstallPath},
 		)
 	}
+	for _, detail := range details {
+		fmt.Printf("      %s %s\n", faint.Sprintf("%-15s", detail.name+":"), detail.value)
+	}
+}
+
+// yesNo returns "yes" if the given value is true, "no" otherwise.
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
 }
 
 func printVenvs() {
@@ -79,6 +175,13 @@ func printVenvs() {
 			if err != nil {
 				log.Fatal(err)
 			}
+			venvArch, err := venv.Arch(venvName)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if venvArch != "" {
+				pythonVersion += ", " + venvArch
+			}
 			line += yellowBold.Sprintf(" (%s)", pythonVersion) + faint.Sprintf(" (%s)", projectPath)
 		}
 		fmt.Println(line)
@@ -87,6 +190,10 @@ func printVenvs() {
 
 func init() {
 	rootCmd.AddCommand(listCmd)
-	listCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "output additional venv information")
+	listCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "output additional venv or Python version information")
 	listCmd.Flags().BoolVar(&execs, "execs", false, "output available Python versions")
+	listCmd.Flags().BoolVar(&refresh, "refresh", false, "ignore the cached Python versions and find them again")
+	listCmd.Flags().StringSliceVar(&providers, "providers", nil, providersUsage)
+	listCmd.Flags().BoolVar(&virtualenvBase, "venv-base", false, virtualenvBaseUsage)
+	listCmd.Flags().StringVar(&arch, "arch", "", archUsage+" (with '--execs')")
 }
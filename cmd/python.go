@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dhruvmanila/pie/pythonfinder"
+)
+
+var pythonCmd = &cobra.Command{
+	Use:   "python",
+	Short: "Manage the Python versions used by pie",
+	Args:  cobra.NoArgs,
+}
+
+var pythonFindCmd = &cobra.Command{
+	Use:   "find [version]",
+	Short: "Find the Python executable for the given version",
+	Long: `Find the Python executable which would be used for the given version, or the
+default Python version if no version is given, and print its path.
+
+The version is interpreted the same way as the '--python' flag of the 'create'
+command. Use the '--explain' flag to trace each provider consulted, each
+Python executable found and why it was rejected.
+
+Like the 'create' command, the Python executables which cannot create a
+virtualenv, e.g., due to the missing 'ensurepip' module, are skipped.
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var version string
+		if len(args) > 0 {
+			version = args[0]
+		}
+
+		finder := newVenvFinder()
+		v, err := finder.Find(cmd.Context(), resolveAlias(loadAliases(), version))
+		printDiagnostics(finder.Diagnostics())
+		if err != nil {
+			if rejected := rejectedBy(err); rejected != nil {
+				fatalRejected(version, rejected)
+			}
+			if errors.Is(err, pythonfinder.ErrVersionNotFound) {
+				if version != "" {
+					log.Fatal(notFoundMessage(cmd.Context(), version, version))
+				}
+				log.Fatal(red.Sprint("✘ No Python version found!"))
+			}
+			log.Fatal(red.Sprintf("✘ %s", err))
+		}
+		fmt.Println(v.Path)
+	},
+}
+
+func init() {
+	pythonFindCmd.Flags().BoolVar(&refresh, "refresh", false, "ignore the cached Python versions and find them again")
+	pythonFindCmd.Flags().StringVar(
+		&prefer, "prefer", "", `choose the "newest" (default) or "oldest" Python version
+when multiple versions match`,
+	)
+	pythonFindCmd.Flags().StringSliceVar(&providers, "providers", nil, providersUsage)
+	pythonFindCmd.Flags().BoolVar(&virtualenvBase, "venv-base", false, virtualenvBaseUsage)
+	pythonFindCmd.Flags().BoolVar(&allowPrereleases, "allow-prereleases", false, allowPrereleasesUsage)
+	pythonFindCmd.Flags().StringVar(&arch, "arch", "", archUsage)
+	pythonFindCmd.Flags().BoolVar(&explain, "explain", false, explainUsage)
+	pythonCmd.AddCommand(pythonFindCmd)
+	rootCmd.AddCommand(pythonCmd)
+}
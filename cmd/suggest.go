@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/dhruvmanila/pie/pythonfinder"
+)
+
+// plainVersionRegex is a regular expression that matches a version request
+// without an implementation or a specifier, e.g., "3.11" or "3.11.9", which
+// can be given as is to the version managers to install it.
+var plainVersionRegex = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+// installers are the commands of the version managers to install a Python
+// version, in the order they're suggested.
+var installers = []struct {
+	// name is the name of the version manager executable.
+	name string
+
+	// command returns the command to install the given version.
+	command func(version string) string
+}{
+	{"uv", func(version string) string {
+		return "uv python install " + version
+	}},
+	{"pyenv", func(version string) string {
+		return "pyenv install " + version
+	}},
+	{"mise", func(version string) string {
+		return "mise install python@" + version
+	}},
+	{"asdf", func(version string) string {
+		if strings.Count(version, ".") < 2 {
+			// asdf only installs the exact versions.
+			version = "latest:" + version
+		}
+		return "asdf install python " + version
+	}},
+	{"rye", func(version string) string {
+		return "rye toolchain fetch " + version
+	}},
+}
+
+// installCommands returns the commands to install the given version using the
+// version managers found in PATH, and pie itself. It returns nil if the
+// version is not a plain version.
+func installCommands(version string) []string {
+	if !plainVersionRegex.MatchString(version) {
+		return nil
+	}
+	var commands []string
+	for _, installer := range installers {
+		if _, err := exec.LookPath(installer.name); err == nil {
+			commands = append(commands, installer.command(version))
+		}
+	}
+	return append(commands, fmt.Sprintf("pie python install %s --from <dir-or-archive>", version))
+}
+
+// suggestVersions returns the available Python versions closest to the given
+// version which could not be found.
+func suggestVersions(ctx context.Context, version string) []*pythonfinder.Installation {
+	// The finder used to find the version might be tracing, which is not
+	// wanted while looking for the suggestions.
+	installations, err := newVenvFinder(pythonfinder.WithTracer(nil)).FindAll(ctx)
+	if err != nil {
+		return nil
+	}
+	return pythonfinder.Suggest(version, installations)
+}
+
+// notFoundMessage returns the message for the given Python version which does
+// not exist, which includes the closest available versions and the commands
+// to install it. The requested version describes it along with its source.
+func notFoundMessage(ctx context.Context, requested, version string) string {
+	var b strings.Builder
+	b.WriteString(red.Sprintf("✘ Python version %s does not exist!", requested))
+	if suggestions := suggestVersions(ctx, version); len(suggestions) > 0 {
+		b.WriteString("\nDid you mean one of these?")
+		for _, v := range suggestions {
+			fmt.Fprintf(&b, "\n  %s %s", yellowBold.Sprint(versionLabel(v.PythonExecutable)), faint.Sprintf("(%s)", v.Path))
+		}
+	}
+	if commands := installCommands(version); len(commands) > 0 {
+		b.WriteString("\nTo install it, run one of:")
+		for _, command := range commands {
+			fmt.Fprintf(&b, "\n  %s", bold.Sprint(command))
+		}
+	}
+	return b.String()
+}
@@ -3,10 +3,12 @@ module github.com/dhruvmanila/pie
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/adrg/xdg v0.4.0
 	github.com/aquasecurity/go-pep440-version v0.0.0-20210121094942-22b2f8951d46
 	github.com/fatih/color v1.15.0
 	github.com/spf13/cobra v1.7.0
+	golang.org/x/sys v0.6.0
 )
 
 require (
@@ -15,6 +17,5 @@ require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.17 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/sys v0.6.0 // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 )
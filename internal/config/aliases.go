@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Aliases maps the user-defined names to the paths of the Python executables
+// they refer to. An alias can be used in place of a Python version, e.g.,
+// "corp-3.11" for a vendor build which is not found by any provider.
+type Aliases map[string]string
+
+// aliasNameRegex is a regular expression that matches a valid alias name,
+// which starts with a letter and cannot contain a path separator.
+var aliasNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*$`)
+
+// versionRequestRegex is a regular expression that matches an alias name
+// which would be parsed as a version request instead, i.e., a Python
+// implementation name alone, or followed by a version or specifier, e.g.,
+// "pypy" or "python3.11".
+var versionRequestRegex = regexp.MustCompile(`^(?i)(cpython|pypy|graalpy|python)(@|\d|$)`)
+
+// ValidateAliasName returns an error if the given name cannot be used as an
+// alias name.
+func ValidateAliasName(name string) error {
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: must start with a letter and only contain letters, digits, '.', '_' or '-'", name)
+	}
+	if versionRequestRegex.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: conflicts with a Python version request", name)
+	}
+	return nil
+}
+
+// LoadAliases reads the aliases from the given TOML file. A missing file
+// results in no aliases.
+func LoadAliases(path string) (Aliases, error) {
+	aliases := make(Aliases)
+	if _, err := toml.DecodeFile(path, &aliases); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return aliases, nil
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// Save writes the aliases to the given TOML file, creating the parent
+// directory if it does not exist. The file is replaced atomically, so that it
+// is not corrupted if the write is interrupted.
+func (a Aliases) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := toml.NewEncoder(file).Encode(a); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	// The temporary file is created only readable by the user, unlike the
+	// file created by os.Create.
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config is the user configuration for pie.
+type Config struct {
+	// Python is the configuration used to find the Python executables.
+	Python Python `toml:"python"`
+}
+
+// Python is the configuration used to find the Python executables.
+type Python struct {
+	// Providers are the providers which are enabled, in the order they're
+	// consulted. A provider name prefixed with "-" disables that provider.
+	Providers []string `toml:"providers"`
+
+	// Prefer is either "newest" or "oldest" which decides the Python version
+	// to choose when multiple versions match the requested version.
+	Prefer string `toml:"prefer"`
+
+	// SearchPaths are the additional directories to search for the Python
+	// executables, which can be glob patterns like "/opt/python-*/bin".
+	SearchPaths []string `toml:"search-paths"`
+
+	// AllowPrereleases allows the pre-releases to be used if no final release
+	// matches the requested version.
+	AllowPrereleases bool `toml:"allow-prereleases"`
+}
+
+// Load reads the configuration from the given TOML file. A missing file
+// results in an empty configuration.
+//
+// An error is returned if the file contains any unknown key, which is most
+// likely a typo.
+func Load(path string) (*Config, error) {
+	var c Config
+	metadata, err := toml.DecodeFile(path, &c)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &c, nil
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, 0, len(undecoded))
+		for _, key := range undecoded {
+			keys = append(keys, key.String())
+		}
+		return nil, fmt.Errorf("%s: unknown keys: %s", path, strings.Join(keys, ", "))
+	}
+	return &c, nil
+}
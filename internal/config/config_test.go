@@ -0,0 +1,129 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dhruvmanila/pie/internal/config"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `
+[python]
+providers = ["pyenv", "-path"]
+prefer = "oldest"
+search-paths = ["/opt/python-*/bin"]
+allow-prereleases = true
+`)
+
+	got, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load(%q) error = %v, want nil", path, err)
+	}
+
+	want := &config.Config{
+		Python: config.Python{
+			Providers:        []string{"pyenv", "-path"},
+			Prefer:           "oldest",
+			SearchPaths:      []string{"/opt/python-*/bin"},
+			AllowPrereleases: true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load(%q) = %+v, want %+v", path, got, want)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	got, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load(%q) error = %v, want nil", path, err)
+	}
+	if !reflect.DeepEqual(got, &config.Config{}) {
+		t.Errorf("Load(%q) = %+v, want empty", path, got)
+	}
+}
+
+func TestLoadUnknownKey(t *testing.T) {
+	path := writeConfig(t, `
+[python]
+provider = ["pyenv"]
+`)
+
+	if _, err := config.Load(path); err == nil {
+		t.Errorf("Load(%q) error = nil, want non-nil", path)
+	}
+}
+
+func TestAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pie", "aliases.toml")
+
+	got, err := config.LoadAliases(path)
+	if err != nil {
+		t.Fatalf("LoadAliases(%q) error = %v, want nil", path, err)
+	}
+	if len(got) != 0 {
+		t.Errorf("LoadAliases(%q) = %v, want empty", path, got)
+	}
+
+	want := config.Aliases{
+		"corp-3.11": "/opt/company/python-3.11/bin/python3",
+		"corp-3.12": "/opt/company/python-3.12/bin/python3",
+	}
+	if err := want.Save(path); err != nil {
+		t.Fatalf("Save(%q) error = %v", path, err)
+	}
+	got, err = config.LoadAliases(path)
+	if err != nil {
+		t.Fatalf("LoadAliases(%q) error = %v, want nil", path, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAliases(%q) = %v, want %v", path, got, want)
+	}
+
+	// The file is replaced without leaving the temporary file behind.
+	delete(want, "corp-3.11")
+	if err := want.Save(path); err != nil {
+		t.Fatalf("Save(%q) error = %v", path, err)
+	}
+	got, err = config.LoadAliases(path)
+	if err != nil {
+		t.Fatalf("LoadAliases(%q) error = %v, want nil", path, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAliases(%q) = %v, want %v", path, got, want)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("ReadDir(%q) = %v, want only %q", filepath.Dir(path), entries, filepath.Base(path))
+	}
+}
+
+func TestValidateAliasName(t *testing.T) {
+	for _, name := range []string{"corp-3.11", "vendor_python", "py3", "python-corp", "pypy_nightly"} {
+		if err := config.ValidateAliasName(name); err != nil {
+			t.Errorf("ValidateAliasName(%q) error = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", "3.11", "-corp", "corp/3.11", "corp 3.11", "pypy", "CPython", "python", "graalpy", "python3.11", "pypy@3.10"} {
+		if err := config.ValidateAliasName(name); err == nil {
+			t.Errorf("ValidateAliasName(%q) error = nil, want non-nil", name)
+		}
+	}
+}
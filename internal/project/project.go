@@ -94,6 +94,13 @@ func (p *Project) WriteProjectFile() error {
 	return os.WriteFile(filepath.Join(p.VenvDir, ".project"), []byte(p.Path), 0o644)
 }
 
+// WriteArchFile records the machine architecture of the Python executable
+// the virtual environment was created from. This is done by writing the
+// architecture in an ".arch" file inside the virtual environment directory.
+func (p *Project) WriteArchFile(arch string) error {
+	return os.WriteFile(filepath.Join(p.VenvDir, ".arch"), []byte(arch), 0o644)
+}
+
 // hashPath returns the hash value of the given path string. It uses the SHA 256
 // algorithm to create the hash value.
 func hashPath(path string) (string, error) {
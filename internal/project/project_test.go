@@ -180,3 +180,25 @@ func TestWriteProjectFile(t *testing.T) {
 		t.Errorf("ReadFile(%q) = %q, want %q", projectFile, b, p.Path)
 	}
 }
+
+func TestWriteArchFile(t *testing.T) {
+	alpha := filepath.Join(testdataDir, "alpha")
+	venvDir := setupVenvDir(t, alpha)
+
+	p, err := New(alpha)
+	if err != nil {
+		t.Fatalf("New(%q) error = %v, want nil", alpha, err)
+	}
+	if err = p.WriteArchFile("aarch64"); err != nil {
+		t.Fatalf("WriteArchFile() error = %v, want nil", err)
+	}
+
+	archFile := filepath.Join(venvDir, ".arch")
+	b, err := os.ReadFile(archFile)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) error = %v, want nil", archFile, err)
+	}
+	if string(b) != "aarch64" {
+		t.Errorf("ReadFile(%q) = %q, want %q", archFile, b, "aarch64")
+	}
+}
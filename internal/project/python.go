@@ -0,0 +1,116 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// pythonVersionFile is the name of the file used by pyenv, and many other
+	// tools, to declare the Python version for a project.
+	pythonVersionFile = ".python-version"
+
+	// pyprojectFile is the name of the Python project metadata file.
+	pyprojectFile = "pyproject.toml"
+)
+
+// PythonRequest contains the Python versions requested by a project.
+type PythonRequest struct {
+	// Versions are the requested Python versions in the order of preference.
+	// Each version is either a version, or a PEP 440 version specifier.
+	Versions []string
+
+	// Source is the name of the file which declared the versions.
+	Source string
+}
+
+// PythonRequest returns the Python versions requested by the project. These
+// are looked up in the following order:
+//  1. The ".python-version" file, which contains one version per line.
+//  2. The "requires-python" key in the "[project]" table of "pyproject.toml".
+//
+// It returns nil if the project does not declare any Python version.
+func (p *Project) PythonRequest() (*PythonRequest, error) {
+	versions, err := readPythonVersionFile(filepath.Join(p.Path, pythonVersionFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if len(versions) > 0 {
+		return &PythonRequest{Versions: versions, Source: pythonVersionFile}, nil
+	}
+
+	requiresPython, err := readRequiresPython(filepath.Join(p.Path, pyprojectFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if requiresPython != "" {
+		return &PythonRequest{Versions: []string{requiresPython}, Source: pyprojectFile}, nil
+	}
+
+	return nil, nil
+}
+
+// readPythonVersionFile reads the versions from the given file which is in
+// the pyenv format, i.e., one version per line where the first available one
+// is used. The empty lines, comments and the "system" version are ignored.
+//
+// The pyenv specific suffix of a version is removed, see pyenvVersion.
+func readPythonVersionFile(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var versions []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || line == "system" {
+			continue
+		}
+		versions = append(versions, pyenvVersion(line))
+	}
+	return versions, scanner.Err()
+}
+
+// pyenvVersionRegex is a regular expression that matches a Python version,
+// optionally prefixed by the implementation, followed by a pyenv specific
+// suffix, e.g., "pypy3.10-7.3.12" or "3.12-dev".
+var pyenvVersionRegex = regexp.MustCompile(`^((?i:cpython|pypy|graalpy|python)?@?\d+(\.\d+)*t?)-.+$`)
+
+// pyenvVersion returns the given version without the pyenv specific suffix,
+// for example, "pypy3.10-7.3.12" becomes "pypy3.10", as it's the version of
+// the implementation and not of Python. The other versions, like a path or
+// "cpython-3.12.1-linux-x86_64-gnu", are returned as is.
+func pyenvVersion(version string) string {
+	if match := pyenvVersionRegex.FindStringSubmatch(version); match != nil {
+		return match[1]
+	}
+	return version
+}
+
+// pyproject contains the subset of the "pyproject.toml" file used by pie.
+type pyproject struct {
+	Project struct {
+		RequiresPython string `toml:"requires-python"`
+	} `toml:"project"`
+}
+
+// readRequiresPython returns the value of the "requires-python" key in the
+// "[project]" table of the given "pyproject.toml" file, which is empty if
+// the key does not exist.
+func readRequiresPython(name string) (string, error) {
+	var metadata pyproject
+	if _, err := toml.DecodeFile(name, &metadata); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(metadata.Project.RequiresPython), nil
+}
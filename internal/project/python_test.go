@@ -0,0 +1,69 @@
+package project
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPythonRequest(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want *PythonRequest
+	}{
+		{
+			// The ".python-version" file takes precedence over "pyproject.toml".
+			dir: "pyversion",
+			want: &PythonRequest{
+				Versions: []string{"3.12", "pypy3.10"},
+				Source:   ".python-version",
+			},
+		},
+		{
+			dir: "pyproject",
+			want: &PythonRequest{
+				Versions: []string{">=3.10,<3.13"},
+				Source:   "pyproject.toml",
+			},
+		},
+		{
+			dir:  "alpha",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			dir := filepath.Join(testdataDir, tt.dir)
+			p, err := New(dir)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v, want nil", dir, err)
+			}
+
+			got, err := p.PythonRequest()
+			if err != nil {
+				t.Fatalf("PythonRequest() error = %v, want nil", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PythonRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPyenvVersion(t *testing.T) {
+	tests := map[string]string{
+		"3.12":                            "3.12",
+		"3.12-dev":                        "3.12",
+		"pypy3.10-7.3.15":                 "pypy3.10",
+		"graalpy-23.1.2":                  "graalpy-23.1.2",
+		"cpython-3.12.1-linux-x86_64-gnu": "cpython-3.12.1-linux-x86_64-gnu",
+		"miniconda3-latest":               "miniconda3-latest",
+		"/opt/python-3.11/bin/python3":    "/opt/python-3.11/bin/python3",
+	}
+	for version, want := range tests {
+		if got := pyenvVersion(version); got != want {
+			t.Errorf("pyenvVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
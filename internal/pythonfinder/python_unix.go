@@ -1,7 +0,0 @@
-//go:build unix
-
-package pythonfinder
-
-import "regexp"
-
-var pythonFileRegex = regexp.MustCompile(`^python(\d(\.\d\d?)?)?$`)
@@ -1,5 +0,0 @@
-package pythonfinder
-
-import "regexp"
-
-var pythonFileRegex = regexp.MustCompile(`^python(\d(\d\d?)?)?\.exe$`)
@@ -0,0 +1,393 @@
+package toolchain
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	pep440Version "github.com/aquasecurity/go-pep440-version"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// ErrNoChecksum is returned when the checksum of an archive could not be
+// found next to it.
+var ErrNoChecksum = errors.New("no checksum found")
+
+// archiveNameRegex is a regular expression that matches the name of an
+// "install_only" python-build-standalone archive, e.g.,
+// "cpython-3.12.3+20240415-x86_64-unknown-linux-gnu-install_only.tar.gz".
+var archiveNameRegex = regexp.MustCompile(
+	`^cpython-(\d+\.\d+\.\d+(?:(?:a|b|rc)\d+)?)\+(\d+)-(.+)-install_only(?:_stripped)?\.(?:tar\.gz|tgz)$`,
+)
+
+// Archive is a python-build-standalone archive containing a Python
+// installation.
+type Archive struct {
+	// Path is the absolute path to the archive.
+	Path string
+
+	// Version is the Python version in the archive.
+	Version string
+
+	// Release is the python-build-standalone release the archive is from,
+	// which is the date it was built on, e.g., "20240415".
+	Release string
+
+	// Target is the platform the archive was built for, e.g.,
+	// "x86_64-unknown-linux-gnu".
+	Target string
+}
+
+// Name returns the name of the toolchain installed from the archive.
+func (a *Archive) Name() string {
+	return fmt.Sprintf("cpython-%s-%s", a.Version, a.Target)
+}
+
+// parseArchive returns the Archive for the given path, or false if its name
+// is not the one of an "install_only" python-build-standalone archive.
+func parseArchive(path string) (*Archive, bool) {
+	m := archiveNameRegex.FindStringSubmatch(filepath.Base(path))
+	if m == nil {
+		return nil, false
+	}
+	return &Archive{Path: path, Version: m[1], Release: m[2], Target: m[3]}, true
+}
+
+// platformTargets maps the operating system and architecture pie is running
+// on to the python-build-standalone target, ignoring the suffixes like
+// "-shared" on Windows.
+var platformTargets = map[string]string{
+	"darwin/amd64":  "x86_64-apple-darwin",
+	"darwin/arm64":  "aarch64-apple-darwin",
+	"linux/386":     "i686-unknown-linux-gnu",
+	"linux/amd64":   "x86_64-unknown-linux-gnu",
+	"linux/arm64":   "aarch64-unknown-linux-gnu",
+	"windows/386":   "i686-pc-windows-msvc",
+	"windows/amd64": "x86_64-pc-windows-msvc",
+	"windows/arm64": "aarch64-pc-windows-msvc",
+}
+
+// isPlatformTarget returns true if the given target is the one of the
+// platform pie is running on.
+func isPlatformTarget(target string) bool {
+	platform, ok := platformTargets[runtime.GOOS+"/"+runtime.GOARCH]
+	return ok && (target == platform || strings.HasPrefix(target, platform+"-"))
+}
+
+// FindArchive returns the archive for the given Python version from the given
+// path, which is either an archive or a directory containing them.
+//
+// If it's a directory, the archive for the newest Python version matching
+// the given version, which can omit the trailing version components, and
+// built for the current platform is returned. The newest release is used for
+// the same version.
+func FindArchive(from, version string) (*Archive, error) {
+	from, err := filepath.Abs(from)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pathutil.IsDir(from) {
+		if _, err := os.Stat(from); err != nil {
+			return nil, err
+		}
+		archive, ok := parseArchive(from)
+		if !ok {
+			return nil, fmt.Errorf("%s: not a python-build-standalone install_only archive", from)
+		}
+		if !matchesVersion(archive.Version, version) {
+			return nil, fmt.Errorf("%s: archive is for Python %s, not %s", from, archive.Version, version)
+		}
+		return archive, nil
+	}
+
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Archive
+	var foundVersion pep440Version.Version
+	for _, entry := range entries {
+		archive, ok := parseArchive(filepath.Join(from, entry.Name()))
+		if !ok || !isPlatformTarget(archive.Target) || !matchesVersion(archive.Version, version) {
+			continue
+		}
+		v, err := pep440Version.Parse(archive.Version)
+		if err != nil {
+			continue
+		}
+		if found == nil || v.GreaterThan(foundVersion) ||
+			(v.Equal(foundVersion) && archive.Release > found.Release) {
+			found, foundVersion = archive, v
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%s: no archive found for Python %s on %s/%s", from, version, runtime.GOOS, runtime.GOARCH)
+	}
+	return found, nil
+}
+
+// Verify checks the SHA-256 checksum of the archive against the one published
+// with it, which is looked up in the following order:
+//  1. The "<archive>.sha256" file next to the archive.
+//  2. The "SHA256SUMS" file in the same directory as the archive.
+//
+// It returns an error wrapping ErrNoChecksum if neither is found.
+func (a *Archive) Verify() error {
+	want, err := a.checksum()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(a.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(got, want) {
+		return fmt.Errorf("%s: checksum mismatch: got %s, want %s", a.Path, got, want)
+	}
+	return nil
+}
+
+// checksum returns the published SHA-256 checksum of the archive.
+func (a *Archive) checksum() (string, error) {
+	content, err := os.ReadFile(a.Path + ".sha256")
+	if err == nil {
+		// The file either contains only the checksum or is in the format
+		// used by the "sha256sum" command.
+		fields := strings.Fields(string(content))
+		if len(fields) > 0 {
+			return fields[0], nil
+		}
+	}
+
+	sums, err := os.Open(filepath.Join(filepath.Dir(a.Path), "SHA256SUMS"))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", a.Path, ErrNoChecksum)
+	}
+	defer sums.Close()
+
+	name := filepath.Base(a.Path)
+	scanner := bufio.NewScanner(sums)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// The file name is prefixed by "*" for the binary mode.
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s: %w", a.Path, ErrNoChecksum)
+}
+
+// Install extracts the archive into the toolchains directory and returns the
+// installed toolchain. The archive should be verified first.
+//
+// The archive is extracted into a hidden directory first, so that a partially
+// extracted toolchain is never found.
+func (a *Archive) Install() (*Toolchain, error) {
+	t := newToolchain(a.Name())
+	if pathutil.IsDir(t.Path) {
+		return nil, fmt.Errorf("toolchain %s is already installed", t.Name)
+	}
+	if err := os.MkdirAll(Dir(), 0o755); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp(Dir(), ".install-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := extract(a.Path, tmpDir); err != nil {
+		return nil, fmt.Errorf("%s: %w", a.Path, err)
+	}
+	// The installation is inside the "python" directory of the archive.
+	if err := os.Rename(filepath.Join(tmpDir, "python"), t.Path); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// extract extracts the given gzip compressed tar archive into the given
+// directory. The entries which would be extracted outside of the directory
+// are rejected.
+func extract(archive, dir string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid archive entry %q", header.Name)
+		}
+		// The symlinks extracted before must not be followed, as an entry
+		// could otherwise be written outside of the directory, e.g., with
+		// "python/a -> ." and "python/a/b -> ..", then "python/a/b/x".
+		if err := checkParents(dir, name); err != nil {
+			return fmt.Errorf("invalid archive entry %q: %w", header.Name, err)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, header.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if path.IsAbs(header.Linkname) {
+				return fmt.Errorf("invalid archive entry %q: links to %q", header.Name, header.Linkname)
+			}
+			// The target is not cleaned, as "a/.." is not the same as "."
+			// if "a" is a symlink.
+			if err := checkLink(dir, path.Dir(name)+"/"+header.Linkname); err != nil {
+				return fmt.Errorf("invalid archive entry %q: links to %q: %w", header.Name, header.Linkname, err)
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			link := path.Clean(header.Linkname)
+			if path.IsAbs(link) || link == ".." || strings.HasPrefix(link, "../") {
+				return fmt.Errorf("invalid archive entry %q: links to %q", header.Name, header.Linkname)
+			}
+			if err := checkParents(dir, link); err != nil {
+				return fmt.Errorf("invalid archive entry %q: links to %q: %w", header.Name, header.Linkname, err)
+			}
+			if err := os.Link(filepath.Join(dir, filepath.FromSlash(link)), target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkParents returns an error if any of the parent directories of the given
+// slash-separated path, relative to the given directory, is a symlink.
+func checkParents(dir, name string) error {
+	parts := strings.Split(name, "/")
+	for i := 1; i < len(parts); i++ {
+		parent := filepath.Join(dir, filepath.FromSlash(path.Join(parts[:i]...)))
+		info, err := os.Lstat(parent)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", path.Join(parts[:i]...))
+		}
+	}
+	return nil
+}
+
+// maxLinks is the maximum number of symlinks followed by checkLink, like the
+// limit of the operating systems.
+const maxLinks = 255
+
+// checkLink returns an error if the given slash-separated path, relative to
+// the given directory, resolves outside of it. The symlinks extracted before
+// are followed, while the missing components are resolved lexically.
+func checkLink(dir, name string) error {
+	parts := strings.Split(name, "/")
+	var resolved []string
+	for links := 0; len(parts) > 0; {
+		part := parts[0]
+		parts = parts[1:]
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if len(resolved) == 0 {
+				return errors.New("outside of the archive")
+			}
+			resolved = resolved[:len(resolved)-1]
+			continue
+		}
+
+		current := filepath.Join(dir, filepath.FromSlash(path.Join(append(resolved, part)...)))
+		info, err := os.Lstat(current)
+		if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+			resolved = append(resolved, part)
+			continue
+		}
+		links++
+		if links > maxLinks {
+			return errors.New("too many links")
+		}
+		target, err := os.Readlink(current)
+		if err != nil {
+			return err
+		}
+		target = filepath.ToSlash(target)
+		if path.IsAbs(target) {
+			return errors.New("outside of the archive")
+		}
+		// The target of the symlink is relative to its directory, which is
+		// the resolved path so far.
+		parts = append(strings.Split(target, "/"), parts...)
+	}
+	return nil
+}
+
+// writeFile writes the content of the given reader to the given file,
+// creating it with the given permissions.
+func writeFile(name string, r io.Reader, perm os.FileMode) error {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm|0o200)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
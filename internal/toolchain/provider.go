@@ -0,0 +1,41 @@
+package toolchain
+
+import (
+	"os"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+	"github.com/dhruvmanila/pie/pythonfinder"
+)
+
+func init() {
+	pythonfinder.Register("pie", func() pythonfinder.Provider {
+		if !pathutil.IsDir(Dir()) {
+			return nil
+		}
+		return provider{}
+	})
+}
+
+// provider is a pythonfinder.Provider that finds the Python executables of
+// the toolchains installed by pie.
+type provider struct{}
+
+func (provider) Name() string {
+	return "pie"
+}
+
+func (provider) Executables() ([]string, error) {
+	toolchains, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var executables []string
+	for _, t := range toolchains {
+		executable := t.Executable()
+		if info, err := os.Stat(executable); err == nil && info.Mode().IsRegular() {
+			executables = append(executables, executable)
+		}
+	}
+	return executables, nil
+}
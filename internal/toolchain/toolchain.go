@@ -0,0 +1,117 @@
+// Package toolchain manages the Python installations extracted by pie from
+// the python-build-standalone archives, which are called toolchains.
+package toolchain
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/dhruvmanila/pie/internal/xdg"
+)
+
+// Dir returns the directory where all the toolchains are installed.
+func Dir() string {
+	return filepath.Join(xdg.DataDir, xdg.ToolchainsDirName)
+}
+
+// Toolchain is a Python installation managed by pie.
+type Toolchain struct {
+	// Name is the name of the toolchain directory, which is of the form
+	// "cpython-<version>-<target>", e.g.,
+	// "cpython-3.12.3-x86_64-unknown-linux-gnu".
+	Name string
+
+	// Version is the Python version of the toolchain.
+	Version string
+
+	// Path is the absolute path to the toolchain directory.
+	Path string
+}
+
+// newToolchain returns the Toolchain for the given directory name.
+func newToolchain(name string) *Toolchain {
+	version := strings.TrimPrefix(name, "cpython-")
+	version, _, _ = strings.Cut(version, "-")
+	return &Toolchain{
+		Name:    name,
+		Version: version,
+		Path:    filepath.Join(Dir(), name),
+	}
+}
+
+// Executable returns the path to the Python executable of the toolchain.
+func (t *Toolchain) Executable() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(t.Path, "python.exe")
+	}
+	return filepath.Join(t.Path, "bin", "python3")
+}
+
+// Contains returns true if the given path is inside the toolchain directory.
+// The symlinks are resolved, as the path might have been resolved, e.g., the
+// home of a virtual environment when the data directory is a symlink.
+func (t *Toolchain) Contains(path string) bool {
+	rel, err := filepath.Rel(resolvePath(t.Path), resolvePath(path))
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resolvePath returns the given path with the symlinks resolved, or as is if
+// it cannot be resolved, e.g., when it does not exist.
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// List returns all the installed toolchains, sorted by their name. The
+// hidden directories, which are used while installing a toolchain, are
+// skipped.
+func List() ([]*Toolchain, error) {
+	entries, err := os.ReadDir(Dir())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var toolchains []*Toolchain
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		toolchains = append(toolchains, newToolchain(entry.Name()))
+	}
+	return toolchains, nil
+}
+
+// Match returns the installed toolchains with the given name, or matching the
+// given version, e.g., "3.12" matches "3.12.3".
+func Match(nameOrVersion string) ([]*Toolchain, error) {
+	toolchains, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Toolchain
+	for _, t := range toolchains {
+		if t.Name == nameOrVersion || matchesVersion(t.Version, nameOrVersion) {
+			matches = append(matches, t)
+		}
+	}
+	return matches, nil
+}
+
+// matchesVersion returns true if the given version matches the requested
+// version, which can omit the trailing version components.
+func matchesVersion(version, requested string) bool {
+	return version == requested || strings.HasPrefix(version, requested+".")
+}
@@ -0,0 +1,304 @@
+package toolchain
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/dhruvmanila/pie/internal/xdg"
+)
+
+// setupDataDir sets the data directory to a temporary directory for the
+// duration of the test.
+func setupDataDir(t *testing.T) {
+	originalDataDir := xdg.DataDir
+	xdg.DataDir = t.TempDir()
+	t.Cleanup(func() {
+		xdg.DataDir = originalDataDir
+	})
+}
+
+// platformTarget returns the python-build-standalone target for the current
+// platform, skipping the test if there is none.
+func platformTarget(t *testing.T) string {
+	target, ok := platformTargets[runtime.GOOS+"/"+runtime.GOARCH]
+	if !ok {
+		t.Skipf("no python-build-standalone target for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	return target
+}
+
+// tarEntry is an entry of the archive written by writeArchive.
+type tarEntry struct {
+	name     string
+	content  string
+	linkname string
+	typeflag byte
+}
+
+// writeArchive writes a gzip compressed tar archive with the given entries
+// to the given directory, along with its checksum in the "SHA256SUMS" file,
+// and returns its path.
+func writeArchive(t *testing.T, dir, name string, entries []tarEntry) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Linkname: entry.linkname,
+			Typeflag: entry.typeflag,
+			Mode:     0o755,
+			Size:     int64(len(entry.content)),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sums, err := os.OpenFile(filepath.Join(dir, "SHA256SUMS"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sums.Close()
+	fmt.Fprintf(sums, "%x  %s\n", sha256.Sum256(buf.Bytes()), name)
+	return path
+}
+
+// pythonEntries returns the archive entries of a fake Python installation.
+func pythonEntries() []tarEntry {
+	if runtime.GOOS == "windows" {
+		return []tarEntry{
+			{name: "python/", typeflag: tar.TypeDir},
+			{name: "python/python.exe", content: "python", typeflag: tar.TypeReg},
+		}
+	}
+	return []tarEntry{
+		{name: "python/", typeflag: tar.TypeDir},
+		{name: "python/bin/", typeflag: tar.TypeDir},
+		{name: "python/bin/python3.12", content: "python", typeflag: tar.TypeReg},
+		{name: "python/bin/python3", linkname: "python3.12", typeflag: tar.TypeSymlink},
+	}
+}
+
+func TestFindArchive(t *testing.T) {
+	target := platformTarget(t)
+	dir := t.TempDir()
+	for _, name := range []string{
+		"cpython-3.11.9+20240415-" + target + "-install_only.tar.gz",
+		"cpython-3.12.2+20240224-" + target + "-install_only.tar.gz",
+		"cpython-3.12.3+20240415-" + target + "-install_only.tar.gz",
+		"cpython-3.12.3+20240107-" + target + "-install_only.tar.gz",
+		"cpython-3.12.3+20240415-riscv64-unknown-linux-gnu-install_only.tar.gz",
+		"cpython-3.12.3+20240415-" + target + "-pgo+lto-full.tar.zst",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "3.12", want: "cpython-3.12.3+20240415-" + target + "-install_only.tar.gz"},
+		{version: "3.12.2", want: "cpython-3.12.2+20240224-" + target + "-install_only.tar.gz"},
+		{version: "3", want: "cpython-3.12.3+20240415-" + target + "-install_only.tar.gz"},
+	}
+	for _, tt := range tests {
+		got, err := FindArchive(dir, tt.version)
+		if err != nil {
+			t.Fatalf("FindArchive(%q) error = %v", tt.version, err)
+		}
+		if want := filepath.Join(dir, tt.want); got.Path != want {
+			t.Errorf("FindArchive(%q) = %q, want %q", tt.version, got.Path, want)
+		}
+	}
+
+	if _, err := FindArchive(dir, "3.13"); err == nil {
+		t.Errorf("FindArchive(%q) error = nil, want non-nil", "3.13")
+	}
+
+	archive := filepath.Join(dir, "cpython-3.11.9+20240415-"+target+"-install_only.tar.gz")
+	got, err := FindArchive(archive, "3.11")
+	if err != nil {
+		t.Fatalf("FindArchive(%q) error = %v", archive, err)
+	}
+	want := &Archive{Path: archive, Version: "3.11.9", Release: "20240415", Target: target}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindArchive(%q) = %+v, want %+v", archive, got, want)
+	}
+	if _, err := FindArchive(archive, "3.12"); err == nil {
+		t.Errorf("FindArchive(%q) error = nil, want a version mismatch", archive)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	path := writeArchive(t, dir, "cpython-3.12.3+20240415-x86_64-unknown-linux-gnu-install_only.tar.gz", pythonEntries())
+	archive, ok := parseArchive(path)
+	if !ok {
+		t.Fatalf("parseArchive(%q) = false, want true", path)
+	}
+	if err := archive.Verify(); err != nil {
+		t.Errorf("Verify() error = %v, want nil", err)
+	}
+
+	// The checksum file next to the archive takes precedence.
+	if err := os.WriteFile(path+".sha256", []byte("0000"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Verify(); err == nil {
+		t.Errorf("Verify() error = nil, want a checksum mismatch")
+	}
+
+	for _, name := range []string{"SHA256SUMS", filepath.Base(path) + ".sha256"} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Verify(); !errors.Is(err, ErrNoChecksum) {
+		t.Errorf("Verify() error = %v, want %v", err, ErrNoChecksum)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	setupDataDir(t)
+	target := platformTarget(t)
+	path := writeArchive(t, t.TempDir(), "cpython-3.12.3+20240415-"+target+"-install_only.tar.gz", pythonEntries())
+	archive, ok := parseArchive(path)
+	if !ok {
+		t.Fatalf("parseArchive(%q) = false, want true", path)
+	}
+
+	installed, err := archive.Install()
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	want := &Toolchain{
+		Name:    "cpython-3.12.3-" + target,
+		Version: "3.12.3",
+		Path:    filepath.Join(Dir(), "cpython-3.12.3-"+target),
+	}
+	if !reflect.DeepEqual(installed, want) {
+		t.Errorf("Install() = %+v, want %+v", installed, want)
+	}
+	if _, err := archive.Install(); err == nil {
+		t.Errorf("Install() error = nil, want already installed")
+	}
+
+	toolchains, err := Match("3.12")
+	if err != nil {
+		t.Fatalf("Match() error = %v", err)
+	}
+	if !reflect.DeepEqual(toolchains, []*Toolchain{want}) {
+		t.Errorf("Match() = %+v, want %+v", toolchains, []*Toolchain{want})
+	}
+
+	executables, err := provider{}.Executables()
+	if err != nil {
+		t.Fatalf("Executables() error = %v", err)
+	}
+	if !reflect.DeepEqual(executables, []string{want.Executable()}) {
+		t.Errorf("Executables() = %q, want %q", executables, []string{want.Executable()})
+	}
+	if !want.Contains(filepath.Dir(want.Executable())) {
+		t.Errorf("Contains(%q) = false, want true", filepath.Dir(want.Executable()))
+	}
+}
+
+func TestContainsSymlinkedDataDir(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), "data")
+	if err := os.Symlink(t.TempDir(), dataDir); err != nil {
+		t.Skipf("Symlink() error = %v", err)
+	}
+	originalDataDir := xdg.DataDir
+	xdg.DataDir = dataDir
+	t.Cleanup(func() {
+		xdg.DataDir = originalDataDir
+	})
+
+	target := platformTarget(t)
+	path := writeArchive(t, t.TempDir(), "cpython-3.12.3+20240415-"+target+"-install_only.tar.gz", pythonEntries())
+	archive, _ := parseArchive(path)
+	installed, err := archive.Install()
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	// The home of a virtual environment created from the resolved executable.
+	home, err := filepath.EvalSymlinks(filepath.Dir(installed.Executable()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !installed.Contains(home) {
+		t.Errorf("Contains(%q) = false, want true", home)
+	}
+	if dir := filepath.Dir(resolvePath(installed.Path)); installed.Contains(dir) {
+		t.Errorf("Contains(%q) = true, want false", dir)
+	}
+}
+
+func TestInstallInvalidEntries(t *testing.T) {
+	setupDataDir(t)
+
+	tests := map[string][]tarEntry{
+		"parent": {
+			{name: "python/../../escape", content: "python", typeflag: tar.TypeReg},
+		},
+		"symlink": {
+			{name: "python/bin/python3", linkname: "../../../usr/bin/python3", typeflag: tar.TypeSymlink},
+		},
+		"absolute symlink": {
+			{name: "python/bin/python3", linkname: "/usr/bin/python3", typeflag: tar.TypeSymlink},
+		},
+		"through symlink": {
+			{name: "python/", typeflag: tar.TypeDir},
+			{name: "python/a", linkname: ".", typeflag: tar.TypeSymlink},
+			{name: "python/a/b", linkname: "..", typeflag: tar.TypeSymlink},
+			{name: "python/b/escape", content: "python", typeflag: tar.TypeReg},
+		},
+		"write through symlink": {
+			{name: "python/", typeflag: tar.TypeDir},
+			{name: "python/lib", linkname: "..", typeflag: tar.TypeSymlink},
+			{name: "python/lib/escape", content: "python", typeflag: tar.TypeReg},
+		},
+		"chained symlink": {
+			{name: "python/", typeflag: tar.TypeDir},
+			{name: "python/lib", linkname: ".", typeflag: tar.TypeSymlink},
+			{name: "python/escape", linkname: "lib/../..", typeflag: tar.TypeSymlink},
+		},
+	}
+	for name, entries := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeArchive(t, t.TempDir(), "cpython-3.12.3+20240415-x86_64-unknown-linux-gnu-install_only.tar.gz", entries)
+			archive, _ := parseArchive(path)
+			if _, err := archive.Install(); err == nil {
+				t.Errorf("Install() error = nil, want invalid archive entry")
+			}
+		})
+	}
+}
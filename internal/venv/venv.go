@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,7 +23,7 @@ func Names() ([]string, error) {
 
 	var venvs []string
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !entry.IsDir() || entry.Name() == xdg.ToolchainsDirName {
 			continue
 		}
 		venvs = append(venvs, entry.Name())
@@ -42,10 +44,39 @@ func ProjectPath(venvName string) (string, error) {
 	return string(content), nil
 }
 
+// Arch returns the machine architecture of the Python executable this
+// environment was created from. This information is extracted from the
+// `.arch` file present in the virtual environment directory, which does not
+// exist for the environments created by older versions, in which case an
+// empty string is returned.
+func Arch(venvName string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(xdg.DataDir, venvName, ".arch"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
 // PythonVersion returns the Python version this environment was created from.
 // This information is extracted from the config file present in the virtual
 // environment directory.
 func PythonVersion(venvName string) (string, error) {
+	return configValue(venvName, "version")
+}
+
+// PythonHome returns the directory containing the Python executable this
+// environment was created from. This information is extracted from the
+// config file present in the virtual environment directory.
+func PythonHome(venvName string) (string, error) {
+	return configValue(venvName, "home")
+}
+
+// configValue returns the value of the given key in the config file present
+// in the virtual environment directory.
+func configValue(venvName, key string) (string, error) {
 	file, err := os.Open(filepath.Join(xdg.DataDir, venvName, "pyvenv.cfg"))
 	if err != nil {
 		return "", err
@@ -54,14 +85,14 @@ func PythonVersion(venvName string) (string, error) {
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		key, value, found := strings.Cut(scanner.Text(), "=")
+		k, value, found := strings.Cut(scanner.Text(), "=")
 		if !found {
 			continue
 		}
-		if strings.TrimSpace(key) == "version" {
+		if strings.TrimSpace(k) == key {
 			return strings.TrimSpace(value), nil
 		}
 	}
 
-	return "", errors.New("venv config file does not contain 'version' key")
+	return "", fmt.Errorf("venv config file does not contain '%s' key", key)
 }
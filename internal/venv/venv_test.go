@@ -1,6 +1,7 @@
 package venv
 
 import (
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -74,3 +75,71 @@ func TestPythonVersion(t *testing.T) {
 		t.Errorf("PythonVersion() = %v, want %s", got, want)
 	}
 }
+
+func TestArch(t *testing.T) {
+	originalDataDir := xdg.DataDir
+	xdg.DataDir = testdataDir
+	t.Cleanup(func() {
+		xdg.DataDir = originalDataDir
+	})
+
+	tests := []struct {
+		venvName string
+		want     string
+	}{
+		{venvName: "venv1", want: "x86_64"},
+		// The environment was created before the architecture was recorded.
+		{venvName: "venv2", want: ""},
+	}
+
+	for _, tt := range tests {
+		got, err := Arch(tt.venvName)
+		if err != nil {
+			t.Fatalf("Arch(%q) error = %v, want nil", tt.venvName, err)
+		}
+		if got != tt.want {
+			t.Errorf("Arch(%q) = %q, want %q", tt.venvName, got, tt.want)
+		}
+	}
+}
+
+func TestNamesSkipsToolchains(t *testing.T) {
+	originalDataDir := xdg.DataDir
+	xdg.DataDir = t.TempDir()
+	t.Cleanup(func() {
+		xdg.DataDir = originalDataDir
+	})
+
+	for _, name := range []string{"project-1a2b3c4d", xdg.ToolchainsDirName} {
+		if err := os.Mkdir(filepath.Join(xdg.DataDir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	venvNames, err := Names()
+	if err != nil {
+		t.Fatalf("Names() error = %v, want nil", err)
+	}
+	want := []string{"project-1a2b3c4d"}
+	if !reflect.DeepEqual(venvNames, want) {
+		t.Errorf("Names() = %v, want %s", venvNames, want)
+	}
+}
+
+func TestPythonHome(t *testing.T) {
+	originalDataDir := xdg.DataDir
+	xdg.DataDir = testdataDir
+	t.Cleanup(func() {
+		xdg.DataDir = originalDataDir
+	})
+
+	got, err := PythonHome("venv1")
+	if err != nil {
+		t.Fatalf("PythonHome() error = %v, want nil", err)
+	}
+
+	want := "/home/user/.python/versions/3.11.0/bin"
+	if got != want {
+		t.Errorf("PythonHome() = %v, want %s", got, want)
+	}
+}
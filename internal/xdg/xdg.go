@@ -16,11 +16,28 @@ const appName = "pie"
 // environments.
 var DataDir string
 
+// ToolchainsDirName is the name of the directory inside DataDir where all the
+// Python toolchains are installed. This is not a virtual environment.
+const ToolchainsDirName = "toolchains"
+
+// CacheDir defines the directory where `pie` stores the non-essential data
+// which can be regenerated, like the information about the Python
+// executables found on the system.
+var CacheDir string
+
+// ConfigDir defines the directory where the user configuration for `pie`
+// is stored. Unlike the other directories, this is not created by `pie`.
+var ConfigDir string
+
 func init() {
 	DataDir = filepath.Join(xdg.DataHome, appName)
-	if _, err := os.Stat(DataDir); errors.Is(err, fs.ErrNotExist) {
-		if err := os.MkdirAll(DataDir, 0o755); err != nil {
-			log.Fatal(err)
+	CacheDir = filepath.Join(xdg.CacheHome, appName)
+	ConfigDir = filepath.Join(xdg.ConfigHome, appName)
+	for _, dir := range []string{DataDir, CacheDir} {
+		if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				log.Fatal(err)
+			}
 		}
 	}
 }
@@ -13,13 +13,24 @@ func TestDataDir(t *testing.T) {
 	if xdg.DataDir == "" {
 		t.Fatal("DataDir is empty")
 	}
+	verifyDir(t, xdg.DataDir)
+}
+
+func TestCacheDir(t *testing.T) {
+	if xdg.CacheDir == "" {
+		t.Fatal("CacheDir is empty")
+	}
+	verifyDir(t, xdg.CacheDir)
+}
 
-	if fi, err := os.Stat(xdg.DataDir); err != nil {
+func verifyDir(t *testing.T, dir string) {
+	t.Helper()
+	if fi, err := os.Stat(dir); err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			t.Errorf("%q: directory does not exist", xdg.DataDir)
+			t.Errorf("%q: directory does not exist", dir)
 		}
-		t.Errorf("%q: stat error = %v", xdg.DataDir, err)
+		t.Errorf("%q: stat error = %v", dir, err)
 	} else if !fi.IsDir() {
-		t.Errorf("%q: not a directory", xdg.DataDir)
+		t.Errorf("%q: not a directory", dir)
 	}
 }
@@ -0,0 +1,64 @@
+package pythonfinder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// archAliases maps the names of the machine architectures reported by the
+// different systems, e.g., "AMD64" on Windows and "arm64" on macOS, to the
+// names used by the Finder.
+var archAliases = map[string]string{
+	"amd64":  "x86_64",
+	"x64":    "x86_64",
+	"arm64":  "aarch64",
+	"i386":   "x86",
+	"i486":   "x86",
+	"i586":   "x86",
+	"i686":   "x86",
+	"armv6l": "arm",
+	"armv7l": "arm",
+	"armv8l": "arm",
+}
+
+// NormalizeArch returns the name used by the Finder for the given machine
+// architecture, e.g., "x86_64" for "AMD64" and "aarch64" for "arm64".
+func NormalizeArch(arch string) string {
+	arch = strings.ToLower(strings.TrimSpace(arch))
+	if alias, ok := archAliases[arch]; ok {
+		return alias
+	}
+	return arch
+}
+
+// Architecture returns the normalized architecture the Python executable was
+// built for.
+//
+// The pointer size is taken into account as the machine reported by a 32-bit
+// build running on a 64-bit system, e.g., "AMD64" on Windows, is the one of
+// the system.
+func (p *PythonExecutable) Architecture() string {
+	arch := NormalizeArch(p.Arch)
+	if p.PointerSize == 32 {
+		switch arch {
+		case "x86_64":
+			return "x86"
+		case "aarch64":
+			return "arm"
+		}
+	}
+	return arch
+}
+
+// ArchFilter returns a Filter which only accepts the Python executables built
+// for the given machine architecture, e.g., "x86_64", "aarch64" or "x86" for
+// a 32-bit build on the x86 architecture.
+func ArchFilter(arch string) Filter {
+	arch = NormalizeArch(arch)
+	return func(pythonExecutable *PythonExecutable) error {
+		if got := pythonExecutable.Architecture(); got != arch {
+			return fmt.Errorf("architecture %s does not match %s", got, arch)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package pythonfinder
+
+import "testing"
+
+func TestArchitecture(t *testing.T) {
+	tests := []struct {
+		arch        string
+		pointerSize int
+		want        string
+	}{
+		{arch: "x86_64", pointerSize: 64, want: "x86_64"},
+		{arch: "AMD64", pointerSize: 64, want: "x86_64"},
+		{arch: "AMD64", pointerSize: 32, want: "x86"},
+		{arch: "i686", pointerSize: 32, want: "x86"},
+		{arch: "arm64", pointerSize: 64, want: "aarch64"},
+		{arch: "aarch64", pointerSize: 32, want: "arm"},
+		{arch: "armv7l", pointerSize: 32, want: "arm"},
+		{arch: "riscv64", pointerSize: 64, want: "riscv64"},
+	}
+
+	for _, tt := range tests {
+		p := &PythonExecutable{Arch: tt.arch, PointerSize: tt.pointerSize}
+		if got := p.Architecture(); got != tt.want {
+			t.Errorf("Architecture() for %s (%d-bit) = %q, want %q", tt.arch, tt.pointerSize, got, tt.want)
+		}
+	}
+}
+
+func TestArchFilter(t *testing.T) {
+	filter := ArchFilter("AMD64")
+
+	if err := filter(&PythonExecutable{Arch: "x86_64", PointerSize: 64}); err != nil {
+		t.Errorf("ArchFilter(%q) error = %v, want nil", "AMD64", err)
+	}
+	if err := filter(&PythonExecutable{Arch: "aarch64", PointerSize: 64}); err == nil {
+		t.Errorf("ArchFilter(%q) error = nil, want aarch64 to be rejected", "AMD64")
+	}
+	if err := filter(&PythonExecutable{Arch: "AMD64", PointerSize: 32}); err == nil {
+		t.Errorf("ArchFilter(%q) error = nil, want a 32-bit build to be rejected", "AMD64")
+	}
+}
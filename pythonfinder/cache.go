@@ -0,0 +1,157 @@
+package pythonfinder
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// cacheFormatVersion is the version of the cache file format. The cache is
+// discarded if the version in the file does not match this, which is useful
+// when the probed information changes.
+const cacheFormatVersion = 2
+
+// cache is an on-disk cache of the information probed from the Python
+// executables, which avoids executing every Python executable each time
+// the finder is used.
+//
+// Each entry is keyed by the absolute, resolved path to the executable and
+// is only valid as long as the file metadata (modification time, size and
+// inode) matches the one recorded while probing. This automatically
+// invalidates the entry when the executable is upgraded or replaced.
+type cache struct {
+	// mu guards the entries, as the executables are probed concurrently.
+	mu sync.Mutex
+
+	// path is the path to the cache file.
+	path string
+
+	// entries is the cached information, keyed by the executable path.
+	entries map[string]cacheEntry
+
+	// dirty is true if the entries were modified since they were loaded.
+	dirty bool
+}
+
+// cacheFile is the on-disk format of the cache file.
+type cacheFile struct {
+	Version int                   `json:"version"`
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+// cacheEntry is the cached information about a single Python executable.
+type cacheEntry struct {
+	Stat fileStat        `json:"stat"`
+	Info interpreterInfo `json:"info"`
+}
+
+// fileStat is the file metadata used to check whether a cache entry is
+// still valid.
+type fileStat struct {
+	ModTime int64  `json:"mtime"`
+	Size    int64  `json:"size"`
+	Inode   uint64 `json:"inode"`
+}
+
+// newFileStat returns the fileStat for the given file info.
+func newFileStat(info fs.FileInfo) fileStat {
+	return fileStat{
+		ModTime: info.ModTime().UnixNano(),
+		Size:    info.Size(),
+		Inode:   inode(info),
+	}
+}
+
+// loadCache loads the cache from the given file path. A missing, unreadable
+// or outdated cache file results in an empty cache, which will be
+// overwritten when saved.
+func loadCache(path string) *cache {
+	c := &cache{path: path, entries: make(map[string]cacheEntry)}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	var file cacheFile
+	if err := json.Unmarshal(content, &file); err != nil || file.Version != cacheFormatVersion {
+		return c
+	}
+	if file.Entries != nil {
+		c.entries = file.Entries
+	}
+	return c
+}
+
+// get returns the cached information for the given executable path if the
+// cache entry is still valid.
+func (c *cache) get(executable string, stat fileStat) (*interpreterInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[executable]
+	if !ok || entry.Stat != stat {
+		return nil, false
+	}
+	info := entry.Info
+	return &info, true
+}
+
+// put records the probed information for the given executable path.
+func (c *cache) put(executable string, info *interpreterInfo, stat fileStat) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[executable] = cacheEntry{Stat: stat, Info: *info}
+	c.dirty = true
+}
+
+// save writes the cache to disk if it was modified. The entries for the
+// executables which no longer exist are removed.
+//
+// The cache file is replaced atomically, so that a concurrent reader never
+// sees a partially written file.
+func (c *cache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for executable := range c.entries {
+		if _, err := os.Stat(executable); errors.Is(err, fs.ErrNotExist) {
+			delete(c.entries, executable)
+			c.dirty = true
+		}
+	}
+	if !c.dirty {
+		return nil
+	}
+
+	content, err := json.Marshal(cacheFile{Version: cacheFormatVersion, Entries: c.entries})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return err
+	}
+
+	c.dirty = false
+	return nil
+}
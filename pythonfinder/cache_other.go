@@ -0,0 +1,12 @@
+//go:build !unix && !windows
+
+package pythonfinder
+
+import "io/fs"
+
+// inode returns the inode number of the file described by the given file
+// info. The other platforms, like js and plan9, do not expose an inode number
+// through the file info, so this always returns 0.
+func inode(_ fs.FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,66 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "cache", "pythons.json")
+	executable := writeFakePython(t, filepath.Join(dir, "bin"))
+
+	info, err := os.Stat(executable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat := newFileStat(info)
+
+	c := loadCache(cachePath)
+	if _, ok := c.get(executable, stat); ok {
+		t.Fatalf("get(%q) on an empty cache = true, want false", executable)
+	}
+
+	c.put(executable, &interpreterInfo{Version: "3.11.0"}, stat)
+	if err := c.save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	c = loadCache(cachePath)
+	got, ok := c.get(executable, stat)
+	if !ok {
+		t.Fatalf("get(%q) after reload = false, want true", executable)
+	}
+	if got.Version != "3.11.0" {
+		t.Errorf("get(%q).Version = %q, want %q", executable, got.Version, "3.11.0")
+	}
+
+	// Modifying the executable should invalidate the entry.
+	changed := stat
+	changed.Size++
+	if _, ok := c.get(executable, changed); ok {
+		t.Errorf("get(%q) with a modified file = true, want false", executable)
+	}
+
+	// The entries for the removed executables are pruned while saving.
+	if err := os.Remove(executable); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+	if c = loadCache(cachePath); len(c.entries) != 0 {
+		t.Errorf("loadCache(%q).entries = %v, want empty", cachePath, c.entries)
+	}
+}
+
+func TestLoadCacheInvalid(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "pythons.json")
+	if err := os.WriteFile(cachePath, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if c := loadCache(cachePath); len(c.entries) != 0 {
+		t.Errorf("loadCache(%q).entries = %v, want empty", cachePath, c.entries)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package pythonfinder
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// inode returns the inode number of the file described by the given file
+// info, or 0 if it's not available.
+func inode(info fs.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Ino)
+	}
+	return 0
+}
@@ -0,0 +1,20 @@
+// Package pythonfinder finds the Python executables available on the system.
+//
+// A Finder consults the providers in order, each of which knows where a
+// specific tool installs the Python executables, e.g., the PATH environment
+// variable, pyenv or uv. The executables found by the providers are probed
+// concurrently to learn their version and other information, which can be
+// cached on disk using the WithCache option.
+//
+// For example, to find the newest Python 3.11 version installed by either
+// pyenv or uv:
+//
+//	finder := pythonfinder.New(pythonfinder.WithProviders([]string{"pyenv", "uv"}))
+//	python, err := finder.Find(ctx, "3.11")
+//	if errors.Is(err, pythonfinder.ErrVersionNotFound) {
+//		// Python 3.11 is not installed.
+//	}
+//
+// Third-party providers implement the Provider interface and are made
+// available to every Finder using Register.
+package pythonfinder
@@ -0,0 +1,72 @@
+package pythonfinder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrVersionNotFound is returned when either the version provided by
+// the user is not found, or there is no version of Python installed
+// on the system.
+var ErrVersionNotFound = errors.New("version does not exist")
+
+// Diagnostic describes a failure which was skipped while finding the Python
+// executables, so that a single broken executable or provider does not
+// prevent finding the other ones.
+type Diagnostic struct {
+	// Provider is the name of the provider which found the executable, or
+	// the one which failed.
+	Provider string
+
+	// Path is the path to the executable, or to the program executed by the
+	// provider which failed, e.g., the version manager resolving the shims.
+	// This is empty if the provider itself failed.
+	Path string
+
+	// Err is the reason for the failure.
+	Err error
+}
+
+func (d Diagnostic) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s provider: %s", d.Provider, d.Err)
+	}
+	return fmt.Sprintf("%s: %s", d.Path, d.Err)
+}
+
+// providerErrors are the failures of a provider which did not prevent it
+// from finding the other executables, each of which is recorded as a
+// Diagnostic of the provider.
+type providerErrors []Diagnostic
+
+func (e providerErrors) Error() string {
+	reasons := make([]string, len(e))
+	for i, d := range e {
+		reasons[i] = d.String()
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// RejectedError is returned when some Python executables match the requested
+// version, but all of them are rejected by the filters. It wraps
+// ErrVersionNotFound.
+type RejectedError struct {
+	// Rejected describes each matching executable and why it was rejected,
+	// in the order of the providers.
+	Rejected []Diagnostic
+}
+
+func (e *RejectedError) Error() string {
+	reasons := make([]string, len(e.Rejected))
+	for i, d := range e.Rejected {
+		reasons[i] = d.String()
+	}
+	return fmt.Sprintf("%s: all the matching Python executables were rejected: %s",
+		ErrVersionNotFound, strings.Join(reasons, "; "),
+	)
+}
+
+func (e *RejectedError) Unwrap() error {
+	return ErrVersionNotFound
+}
@@ -0,0 +1,65 @@
+package pythonfinder_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/dhruvmanila/pie/pythonfinder"
+)
+
+func ExampleFinder_Find() {
+	finder := pythonfinder.New(
+		pythonfinder.WithProviders([]string{"pyenv", "uv", "path"}),
+		pythonfinder.WithPreference(pythonfinder.PreferOldest),
+	)
+	python, err := finder.Find(context.Background(), ">=3.10")
+	if errors.Is(err, pythonfinder.ErrVersionNotFound) {
+		log.Fatal("Python 3.10 or later is not installed")
+	} else if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(python.Path)
+}
+
+func ExampleFinder_FindAll() {
+	finder := pythonfinder.New()
+	installations, err := finder.FindAll(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, installation := range installations {
+		fmt.Printf("%s found by %v at %s\n", installation.Version, installation.Providers, installation.Path)
+	}
+	for _, d := range finder.Diagnostics() {
+		fmt.Fprintf(os.Stderr, "skipped %s\n", d)
+	}
+}
+
+// toolProvider finds the Python executables installed by a hypothetical
+// tool in its data directory.
+type toolProvider struct {
+	root string
+}
+
+func (p *toolProvider) Name() string {
+	return "tool"
+}
+
+func (p *toolProvider) Executables() ([]string, error) {
+	return filepath.Glob(filepath.Join(p.root, "*", "bin", "python3"))
+}
+
+func ExampleRegister() {
+	pythonfinder.Register("tool", func() pythonfinder.Provider {
+		root := os.Getenv("TOOL_HOME")
+		if root == "" {
+			// The tool is not installed.
+			return nil
+		}
+		return &toolProvider{root: root}
+	})
+}
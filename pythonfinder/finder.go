@@ -0,0 +1,800 @@
+package pythonfinder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+var evalSymlinks = filepath.EvalSymlinks
+
+// finderStrategy is the strategy used by the finder to find Python executables.
+//
+// This is decided as per the given version.
+type finderStrategy int
+
+const (
+	// findAll finds all Python executables.
+	findAll finderStrategy = iota
+
+	// findFirst finds the first Python executable.
+	findFirst
+
+	// findExact finds the Python executable which matches the given version
+	// exactly.
+	findExact
+
+	// findGlob finds the Python executable which matches the given version
+	// using glob matching.
+	findGlob
+
+	// findSpecifier finds the Python executable which satisfies the given
+	// PEP 440 version specifier.
+	findSpecifier
+)
+
+func (s finderStrategy) String() string {
+	switch s {
+	case findAll:
+		return "findAll"
+	case findFirst:
+		return "findFirst"
+	case findExact:
+		return "findExact"
+	case findGlob:
+		return "findGlob"
+	case findSpecifier:
+		return "findSpecifier"
+	default:
+		return "unknown"
+	}
+}
+
+// Finder finds the Python executables available on the system by consulting
+// the providers in order. A Finder should be created using New, and is not
+// safe for concurrent use.
+type Finder struct {
+	providers []Provider
+
+	// cachePath is the path to the cache file for the probed Python
+	// executables. The cache is disabled if this is empty.
+	cachePath string
+
+	// refresh is true if the cached entries should be ignored, probing all
+	// the Python executables again.
+	refresh bool
+
+	// concurrency is the maximum number of Python executables probed
+	// concurrently.
+	concurrency int
+
+	// probeTimeout is the maximum duration a single Python executable is
+	// given to respond to a probe.
+	probeTimeout time.Duration
+
+	// preference decides which Python executable is chosen when multiple
+	// executables match the requested version.
+	preference Preference
+
+	// providerNames are the names of the enabled providers in the order
+	// they're consulted. All the providers are enabled in the default order
+	// if this is nil.
+	providerNames []string
+
+	// virtualenvBase is true if the Python executables inside a virtual
+	// environment are replaced by the base executable they point to instead
+	// of being skipped.
+	virtualenvBase bool
+
+	// allowPrereleases is true if the pre-releases can be found when the
+	// requested version is not a complete version.
+	allowPrereleases bool
+
+	// tracer is called for each decision made by the finder, if set.
+	tracer Tracer
+
+	// searchPaths are the additional directories, or glob patterns, which
+	// are searched for the Python executables by the search-paths provider.
+	searchPaths []string
+
+	// filters are used to reject the Python executables before matching
+	// them against the requested version.
+	filters []Filter
+
+	// diagnostics are the failures which were skipped during the last
+	// search.
+	diagnostics []Diagnostic
+}
+
+// Preference decides which Python executable is chosen when multiple
+// executables match the requested version, i.e., for a version like "3.11"
+// or a version specifier like ">=3.10,<3.13".
+type Preference int
+
+const (
+	// PreferNewest chooses the newest matching Python version.
+	PreferNewest Preference = iota
+
+	// PreferOldest chooses the oldest matching Python version.
+	PreferOldest
+)
+
+// ParsePreference parses the given preference name, which is either "newest"
+// or "oldest".
+func ParsePreference(name string) (Preference, error) {
+	switch name {
+	case "newest":
+		return PreferNewest, nil
+	case "oldest":
+		return PreferOldest, nil
+	default:
+		return 0, fmt.Errorf("invalid preference %q: must be either \"newest\" or \"oldest\"", name)
+	}
+}
+
+func (p Preference) String() string {
+	switch p {
+	case PreferNewest:
+		return "newest"
+	case PreferOldest:
+		return "oldest"
+	default:
+		return "unknown"
+	}
+}
+
+// defaultProbeTimeout is the default maximum duration a single Python
+// executable is given to respond to a probe.
+const defaultProbeTimeout = 10 * time.Second
+
+// Option is used to configure the Finder.
+type Option func(*Finder)
+
+// WithCache returns an Option which enables caching the information probed
+// from the Python executables in the given file.
+func WithCache(path string) Option {
+	return func(f *Finder) {
+		f.cachePath = path
+	}
+}
+
+// WithRefresh returns an Option which ignores any cached information, probing
+// all the Python executables again. The cache, if enabled, is updated with
+// the new information.
+func WithRefresh(refresh bool) Option {
+	return func(f *Finder) {
+		f.refresh = refresh
+	}
+}
+
+// WithConcurrency returns an Option which sets the maximum number of Python
+// executables probed concurrently. It defaults to the number of CPUs.
+func WithConcurrency(n int) Option {
+	return func(f *Finder) {
+		if n > 0 {
+			f.concurrency = n
+		}
+	}
+}
+
+// WithProbeTimeout returns an Option which sets the maximum duration a single
+// Python executable is given to respond to a probe, after which the
+// executable is skipped.
+func WithProbeTimeout(timeout time.Duration) Option {
+	return func(f *Finder) {
+		if timeout > 0 {
+			f.probeTimeout = timeout
+		}
+	}
+}
+
+// WithPreference returns an Option which sets the Preference used to choose
+// among the multiple Python executables matching the requested version. It
+// defaults to PreferNewest.
+func WithPreference(preference Preference) Option {
+	return func(f *Finder) {
+		f.preference = preference
+	}
+}
+
+// WithProviders returns an Option which only enables the providers with the
+// given names, in the given order. The names should be validated using
+// ParseProviders, as the unknown names are ignored.
+func WithProviders(names []string) Option {
+	return func(f *Finder) {
+		f.providerNames = names
+	}
+}
+
+// WithSearchPaths returns an Option which enables the "search-paths" provider
+// to find the Python executables in the given directories. A directory can be
+// a glob pattern as per filepath.Match, e.g., "/opt/python-*/bin", and can
+// start with "~" to refer to the home directory.
+func WithSearchPaths(patterns []string) Option {
+	return func(f *Finder) {
+		f.searchPaths = patterns
+	}
+}
+
+// WithPrereleases returns an Option which allows the pre-releases to be found
+// when the requested version is not a complete version or is a specifier,
+// e.g., 3.14.0b3 for 3.14 or ">=3.14", if no final release matches it. Otherwise, the pre-releases are only
+// found when requested exactly.
+func WithPrereleases(allow bool) Option {
+	return func(f *Finder) {
+		f.allowPrereleases = allow
+	}
+}
+
+// WithVirtualenvBase returns an Option which replaces the Python executables
+// inside a virtual environment, e.g., the one of an activated virtual
+// environment in PATH, by the base executable they point to. Otherwise, such
+// executables are skipped.
+func WithVirtualenvBase(virtualenvBase bool) Option {
+	return func(f *Finder) {
+		f.virtualenvBase = virtualenvBase
+	}
+}
+
+// WithTracer returns an Option which calls the given Tracer for each decision
+// made by the finder, which is useful to explain why a Python version was
+// found or not.
+func WithTracer(tracer Tracer) Option {
+	return func(f *Finder) {
+		f.tracer = tracer
+	}
+}
+
+// Filter decides whether the given Python executable should be considered by
+// the Finder. It returns nil to accept the executable, or an error describing
+// why it was rejected otherwise.
+type Filter func(pythonExecutable *PythonExecutable) error
+
+// WithFilter returns an Option which only considers the Python executables
+// accepted by the given Filter. This can be used multiple times, in which
+// case an executable must be accepted by all the filters.
+//
+// If the Python executables matching the requested version are all rejected
+// by the filters, Find returns a RejectedError describing why.
+func WithFilter(filter Filter) Option {
+	return func(f *Finder) {
+		f.filters = append(f.filters, filter)
+	}
+}
+
+// New returns a new Finder configured using the given options.
+func New(opts ...Option) *Finder {
+	f := &Finder{
+		concurrency:  runtime.NumCPU(),
+		probeTimeout: defaultProbeTimeout,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.setupProviders()
+	return f
+}
+
+// Find returns the Python version which matches the given version, if provided,
+// or the first version found by the providers.
+//
+// The version can be prefixed with the name of a Python implementation, e.g.,
+// "pypy3.10", "cpython@3.12" or "graalpy", to only consider the Python
+// executables of that implementation. The free-threaded builds are only
+// considered if the version has the "t" suffix, e.g., "3.13t".
+//
+// The strategy used to find the Python version is decided as per the given
+// version using the following rules:
+//  1. If the given version is empty, find the first Python version.
+//  2. If the given version is a PEP 440 version specifier, e.g., ">=3.10,<3.13"
+//     or "~=3.11", find the preferred version among all the Python versions
+//     which satisfy the specifier.
+//  3. If the given version is a final release and not a complete version, find
+//     the preferred version among all the Python versions which match it. For
+//     example, if the given version is 3.11, find the preferred version among
+//     all the Python versions which match 3.11.*.
+//  4. Otherwise, find the Python version which matches the given version exactly.
+//
+// The preferred version is the newest one by default, which can be changed
+// using the WithPreference option. A final release is always preferred over a
+// pre-release, which only match the version in rules 2 and 3 if the
+// WithPrereleases option is set. When no implementation is requested, CPython is always
+// preferred, and the other implementations are only used if no CPython
+// version matches.
+//
+// A final release is a version which is not a pre-release, post-release, or
+// developmental release.
+//
+// A complete version is a version which has all the version components and
+// is a final release. For example, 3.11.2 is a complete version but 3.11 is
+// not.
+//
+// The version can also be a path to a Python executable, i.e., containing a
+// path separator, in which case that executable is used as is without
+// consulting the providers.
+//
+// The search stops with the context error if the given context is done.
+func (f *Finder) Find(ctx context.Context, version string) (*PythonExecutable, error) {
+	if isPath(version) {
+		return f.findPath(ctx, version)
+	}
+
+	request, err := parseVersionRequest(version)
+	if err != nil {
+		return nil, err
+	}
+	request.allowPrereleases = f.allowPrereleases
+
+	versions, err := f.find(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// There is going to be exactly one version in the slice. This is ensured
+	// by the find() function. Otherwise, it would return ErrVersionNotFound.
+	return versions[0].PythonExecutable, nil
+}
+
+// isPath returns true if the given version request is a path to a Python
+// executable instead of a version.
+func isPath(version string) bool {
+	return strings.ContainsRune(version, '/') || strings.ContainsRune(version, filepath.Separator)
+}
+
+// findPath returns the Python executable at the given path, after checking
+// that it's a supported Python executable accepted by the filters. The
+// returned error wraps ErrVersionNotFound if the path does not exist.
+func (f *Finder) findPath(ctx context.Context, path string) (*PythonExecutable, error) {
+	f.diagnostics = nil
+
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := evalSymlinks(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w", path, ErrVersionNotFound)
+		}
+		return nil, err
+	}
+
+	var c *cache
+	if f.cachePath != "" {
+		c = loadCache(f.cachePath)
+		defer func() {
+			_ = c.save()
+		}()
+	}
+
+	f.trace(TraceEvent{Kind: TraceCandidate, Path: path})
+	pythonExecutable, err := f.probe(ctx, c, resolved)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%s is not a usable Python executable: %w", path, probeError(err, f.probeTimeout))
+	}
+	f.trace(TraceEvent{Kind: TraceProbed, Path: resolved, PythonExecutable: pythonExecutable})
+
+	if pythonExecutable.Version.LessThan(minimumVersion) {
+		return nil, fmt.Errorf("%s: Python %s is not supported", path, pythonExecutable.Version)
+	}
+	if err := f.filter(pythonExecutable); err != nil {
+		return nil, &RejectedError{Rejected: []Diagnostic{{Path: path, Err: err}}}
+	}
+	f.trace(TraceEvent{Kind: TraceSelected, Path: resolved, PythonExecutable: pythonExecutable})
+	return pythonExecutable, nil
+}
+
+// Installation is a single Python installation found by the providers. The
+// same executable can be found through multiple paths, e.g., the "python3"
+// and "python3.11" symlinks, and by multiple providers.
+type Installation struct {
+	*PythonExecutable
+
+	// Aliases are the paths through which the executable was found, in the
+	// order they were found. This includes the executable path itself only
+	// if it was found directly.
+	Aliases []string
+
+	// Providers are the names of the providers which found the executable,
+	// in the order they're consulted.
+	Providers []string
+}
+
+// Diagnostics returns the failures which were skipped during the last call to
+// Find or FindAll, e.g., a Python executable which could not be probed, in
+// the order of the providers.
+func (f *Finder) Diagnostics() []Diagnostic {
+	return f.diagnostics
+}
+
+// FindAll returns all the Python installations available on the system which
+// can be found by the providers, one per resolved executable.
+//
+// The search stops with the context error if the given context is done.
+func (f *Finder) FindAll(ctx context.Context) ([]*Installation, error) {
+	return f.find(ctx, &versionRequest{strategy: findAll})
+}
+
+func (f *Finder) find(ctx context.Context, request *versionRequest) ([]*Installation, error) {
+	var versions []*Installation
+	// preferred is the preferred installation of each rank.
+	var preferred [numRanks]*Installation
+	var rejected []Diagnostic
+	f.diagnostics = nil
+
+	var c *cache
+	if f.cachePath != "" {
+		c = loadCache(f.cachePath)
+		defer func() {
+			// The cache is only an optimization, so failing to write it
+			// should not fail the search.
+			_ = c.save()
+		}()
+	}
+
+	candidates := f.candidates(ctx)
+
+	ctx, cancel := context.WithCancel(ctx)
+	results, wait := f.probeAll(ctx, c, candidates)
+	defer func() {
+		// Stop the remaining probes and wait for the workers to finish
+		// before the cache is saved.
+		cancel()
+		wait()
+	}()
+
+	// The results are consumed in the order of the candidates, which is the
+	// order of the providers, so the strategies which stop at the first
+	// match respect the provider priority.
+CandidateLoop:
+	for i, candidate := range candidates {
+		var result probeResult
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			// The probe for this candidate might never be started.
+			return nil, ctx.Err()
+		}
+		pythonExecutable, err := result.pythonExecutable, result.err
+		if err != nil {
+			if ctx.Err() != nil {
+				// The probe failed because the search was stopped.
+				return nil, ctx.Err()
+			}
+			// A single broken executable should not prevent finding the
+			// other ones, so the failure is recorded and skipped.
+			f.skip(candidate, probeError(err, f.probeTimeout))
+			continue
+		}
+		if a, ok := candidate.provider.(annotator); ok {
+			a.annotate(pythonExecutable)
+		}
+		f.trace(TraceEvent{
+			Kind:             TraceProbed,
+			Provider:         candidate.provider.Name(),
+			Path:             candidate.path,
+			PythonExecutable: pythonExecutable,
+		})
+
+		if pythonExecutable.Version.LessThan(minimumVersion) {
+			f.reject(candidate, pythonExecutable, fmt.Sprintf("Python %s is not supported", pythonExecutable.Version))
+			continue
+		}
+		if pythonExecutable.IsVirtualenv {
+			// A virtual environment without the configuration file, e.g.,
+			// the ones created by the legacy virtualenv, which can only be
+			// detected by probing the executable.
+			f.reject(candidate, pythonExecutable, "inside a virtual environment")
+			continue
+		}
+		if request.strategy != findAll {
+			if reason := request.mismatch(pythonExecutable); reason != "" {
+				f.reject(candidate, pythonExecutable, reason)
+				continue
+			}
+		}
+		// The filters are applied after matching the requested version, so
+		// that the executables which match but are rejected can be reported
+		// if no other executable is usable.
+		if err := f.filter(pythonExecutable); err != nil {
+			rejected = append(rejected, Diagnostic{Provider: candidate.provider.Name(), Path: candidate.path, Err: err})
+			f.reject(candidate, pythonExecutable, err.Error())
+			continue
+		}
+
+		installation := &Installation{
+			PythonExecutable: pythonExecutable,
+			Aliases:          candidate.aliases,
+			Providers:        candidate.providers,
+		}
+
+		switch request.strategy {
+		case findAll:
+			versions = append(versions, installation)
+		case findFirst, findExact:
+			rank := request.rank(pythonExecutable)
+			if preferred[rank] == nil {
+				preferred[rank] = installation
+			}
+			if rank == 0 {
+				break CandidateLoop
+			}
+			// The other implementations are only used if no CPython
+			// executable is found.
+			f.trace(TraceEvent{
+				Kind:             TraceMatched,
+				Provider:         candidate.provider.Name(),
+				Path:             candidate.path,
+				PythonExecutable: pythonExecutable,
+			})
+		case findGlob, findSpecifier:
+			f.trace(TraceEvent{
+				Kind:             TraceMatched,
+				Provider:         candidate.provider.Name(),
+				Path:             candidate.path,
+				PythonExecutable: pythonExecutable,
+			})
+			if rank := request.rank(pythonExecutable); f.prefers(installation, preferred[rank]) {
+				preferred[rank] = installation
+			}
+		}
+	}
+
+	// The other implementations, when no implementation is requested, and
+	// the pre-releases, if allowed, are only used if no better ranked
+	// installation matches the requested version.
+	for _, installation := range preferred {
+		if installation != nil {
+			versions = append(versions, installation)
+			f.traceSelected(installation)
+			break
+		}
+	}
+
+	// This either means that the version provided by the user does not exist,
+	// or there is no version of Python installed on the system.
+	if len(versions) == 0 {
+		if len(rejected) > 0 {
+			return nil, &RejectedError{Rejected: rejected}
+		}
+		return nil, ErrVersionNotFound
+	}
+
+	return versions, nil
+}
+
+// filter returns the error of the first Filter which rejects the given Python
+// executable, or nil if all the filters accept it.
+func (f *Finder) filter(pythonExecutable *PythonExecutable) error {
+	for _, filter := range f.filters {
+		if err := filter(pythonExecutable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// probeError returns the error describing why probing a Python executable
+// failed, given the error returned by the probe.
+func probeError(err error, timeout time.Duration) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		// The executable did not respond in time, which could be due to a
+		// broken mount or a script waiting for input.
+		return fmt.Errorf("probe timed out after %s", timeout)
+	}
+	switch err := err.(type) {
+	case *exec.Error:
+		return fmt.Errorf("not executable: %w", err)
+	case *exec.ExitError:
+		// The first line of the output usually explains the failure, e.g.,
+		// the pyenv shim for a version which is not installed.
+		if stderr, _, _ := strings.Cut(strings.TrimSpace(string(err.Stderr)), "\n"); stderr != "" {
+			return fmt.Errorf("probe failed with %w: %s", err, stderr)
+		}
+		return fmt.Errorf("probe failed with %w", err)
+	}
+	return err
+}
+
+// skip records the given failure for the given candidate as a Diagnostic and
+// emits the TraceRejected event for it.
+func (f *Finder) skip(c candidate, err error) {
+	f.diagnostics = append(f.diagnostics, Diagnostic{Provider: c.provider.Name(), Path: c.path, Err: err})
+	f.reject(c, nil, err.Error())
+}
+
+// reject emits the TraceRejected event for the given candidate with the given
+// reason. The Python executable is nil if the candidate was not probed.
+func (f *Finder) reject(c candidate, pythonExecutable *PythonExecutable, reason string) {
+	f.trace(TraceEvent{
+		Kind:             TraceRejected,
+		Provider:         c.provider.Name(),
+		Path:             c.path,
+		PythonExecutable: pythonExecutable,
+		Reason:           reason,
+	})
+}
+
+// traceSelected emits the TraceSelected event for the given installation.
+func (f *Finder) traceSelected(installation *Installation) {
+	f.trace(TraceEvent{
+		Kind:             TraceSelected,
+		Provider:         installation.Providers[0],
+		Path:             installation.Path,
+		PythonExecutable: installation.PythonExecutable,
+	})
+}
+
+// prefers returns true if the given Python installation is preferred over the
+// current one as per the finder Preference. Any installation is preferred
+// over a nil one. For the same version, the current one is kept to respect
+// the provider priority.
+func (f *Finder) prefers(installation, current *Installation) bool {
+	if current == nil {
+		return true
+	}
+	if f.preference == PreferOldest {
+		return installation.Version.LessThan(*current.Version)
+	}
+	return installation.Version.GreaterThan(*current.Version)
+}
+
+// candidates returns the Python executables found by all the providers, in
+// the order of the providers. The paths found by the providers are resolved,
+// so an executable found through multiple paths or by multiple providers is
+// only included once, for the first provider which found it.
+//
+// The executables inside a virtual environment are skipped, or replaced by
+// their base executable if the virtualenvBase option is set.
+func (f *Finder) candidates(ctx context.Context) []candidate {
+	var candidates []candidate
+
+	// seen maps the resolved path of the Python executables which were
+	// already seen by the providers to their index in candidates. This is
+	// used to group the aliases of the same executable together.
+	seen := make(map[string]int)
+
+	for _, p := range f.providers {
+		f.trace(TraceEvent{Kind: TraceProvider, Provider: p.Name()})
+		var executables []string
+		var err error
+		if cp, ok := p.(contextProvider); ok {
+			executables, err = cp.executablesContext(ctx)
+		} else {
+			executables, err = p.Executables()
+		}
+		var errs providerErrors
+		if errors.As(err, &errs) {
+			for _, d := range errs {
+				d.Provider = p.Name()
+				f.diagnostics = append(f.diagnostics, d)
+				f.trace(TraceEvent{Kind: TraceRejected, Provider: p.Name(), Path: d.Path, Reason: d.Err.Error()})
+			}
+		} else if err != nil {
+			// The executables found by the provider before the failure
+			// are still considered.
+			f.diagnostics = append(f.diagnostics, Diagnostic{Provider: p.Name(), Err: err})
+		}
+		for _, executable := range executables {
+			f.trace(TraceEvent{Kind: TraceCandidate, Provider: p.Name(), Path: executable})
+
+			if info, err := os.Stat(executable); err == nil && !isExecutable(info) {
+				f.trace(TraceEvent{
+					Kind:     TraceRejected,
+					Provider: p.Name(),
+					Path:     executable,
+					Reason:   "not executable",
+				})
+				continue
+			}
+
+			var resolved string
+			if configPath := findPyvenvConfig(executable); configPath != "" {
+				if !f.virtualenvBase {
+					f.trace(TraceEvent{
+						Kind:     TraceRejected,
+						Provider: p.Name(),
+						Path:     executable,
+						Reason:   "inside a virtual environment",
+					})
+					continue
+				}
+				resolved, err = baseExecutable(executable, configPath)
+				if err != nil {
+					// The virtual environment is broken, e.g., the base
+					// installation was removed.
+					f.trace(TraceEvent{
+						Kind:     TraceRejected,
+						Provider: p.Name(),
+						Path:     executable,
+						Reason:   fmt.Sprintf("inside a virtual environment: %s", err),
+					})
+					continue
+				}
+				// The virtual environment executable is not an alias of
+				// the base executable.
+				executable = resolved
+			} else {
+				resolved, err = evalSymlinks(executable)
+				if err != nil {
+					// This is usually a broken symlink.
+					f.diagnostics = append(f.diagnostics, Diagnostic{Provider: p.Name(), Path: executable, Err: err})
+					f.trace(TraceEvent{
+						Kind:     TraceRejected,
+						Provider: p.Name(),
+						Path:     executable,
+						Reason:   err.Error(),
+					})
+					continue
+				}
+			}
+
+			i, ok := seen[resolved]
+			if ok {
+				f.trace(TraceEvent{
+					Kind:     TraceRejected,
+					Provider: p.Name(),
+					Path:     executable,
+					Reason:   fmt.Sprintf("duplicate of %s", resolved),
+				})
+			} else {
+				i = len(candidates)
+				seen[resolved] = i
+				candidates = append(candidates, candidate{provider: p, path: resolved})
+			}
+			c := &candidates[i]
+			if !contains(c.aliases, executable) {
+				c.aliases = append(c.aliases, executable)
+			}
+			if !contains(c.providers, p.Name()) {
+				c.providers = append(c.providers, p.Name())
+			}
+		}
+	}
+
+	return candidates
+}
+
+// contains returns true if the given slice contains the given value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// setupProviders sets up the enabled providers in order, skipping the ones
+// which are not available on the system.
+func (f *Finder) setupProviders() {
+	names := f.providerNames
+	if names == nil {
+		names = ProviderNames()
+	}
+	factories := factories()
+	for _, name := range names {
+		for _, factory := range factories {
+			if factory.name != name {
+				continue
+			}
+			if p := factory.new(f); p != nil {
+				f.providers = append(f.providers, p)
+			}
+		}
+	}
+}
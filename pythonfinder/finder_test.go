@@ -0,0 +1,544 @@
+package pythonfinder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeProvider is a Provider which returns the given executables.
+type fakeProvider []string
+
+func (p fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p fakeProvider) Executables() ([]string, error) {
+	return p, nil
+}
+
+// fakePython returns the path to a fake Python executable which reports
+// the given version when run using the "TestFind" helper process.
+func fakePython(version string) string {
+	return filepath.Join(string(filepath.Separator), version, "python")
+}
+
+// setupFakeFinder returns a finder using the given providers where all the
+// executables are run using the "TestFind" helper process.
+func setupFakeFinder(t *testing.T, providers ...Provider) *Finder {
+	testCaseName = "TestFind"
+	execCommandContext = fakeExecCommandContext
+	// The fake executables do not exist, so they're considered resolved.
+	evalSymlinks = func(path string) (string, error) { return path, nil }
+	t.Cleanup(func() {
+		execCommandContext = exec.CommandContext
+		evalSymlinks = filepath.EvalSymlinks
+	})
+
+	f := New(WithConcurrency(4), WithProbeTimeout(5*time.Second))
+	f.providers = providers
+	return f
+}
+
+func TestFind(t *testing.T) {
+	f := setupFakeFinder(t,
+		fakeProvider{fakePython("3.10.4"), fakePython("3.11.1")},
+		fakeProvider{fakePython("3.11.1"), fakePython("3.11.4"), fakePython("3.12.0a1")},
+	)
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "", want: fakePython("3.10.4")},
+		{version: "3.11", want: fakePython("3.11.4")},
+		{version: "3", want: fakePython("3.11.4")},
+		{version: "3.11.1", want: fakePython("3.11.1")},
+		{version: "3.12.0a1", want: fakePython("3.12.0a1")},
+		{version: ">=3.10,<3.12", want: fakePython("3.11.4")},
+		{version: "~=3.10.0", want: fakePython("3.10.4")},
+		{version: "<3.11.4", want: fakePython("3.11.1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := f.Find(context.Background(), tt.version)
+			if err != nil {
+				t.Fatalf("Find(%q) error = %v", tt.version, err)
+			}
+			if got.Path != tt.want {
+				t.Errorf("Find(%q) = %q, want %q", tt.version, got.Path, tt.want)
+			}
+		})
+	}
+
+	if _, err := f.Find(context.Background(), "3.9"); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Find(%q) error = %v, want %v", "3.9", err, ErrVersionNotFound)
+	}
+}
+
+func TestFindImplementation(t *testing.T) {
+	// The other implementations are found first, like "/usr/bin/pypy3" and
+	// "/usr/bin/python3".
+	f := setupFakeFinder(t,
+		fakeProvider{fakePython("pypy-3.10.14"), fakePython("3.11.4")},
+		fakeProvider{fakePython("graalpy-3.11.7"), fakePython("pypy-3.9.18"), fakePython("3.12.1")},
+	)
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "pypy", want: fakePython("pypy-3.10.14")},
+		{version: "pypy3", want: fakePython("pypy-3.10.14")},
+		{version: "pypy3.9", want: fakePython("pypy-3.9.18")},
+		{version: "graalpy", want: fakePython("graalpy-3.11.7")},
+		{version: "cpython@3.11", want: fakePython("3.11.4")},
+		{version: "cpython@>=3.11", want: fakePython("3.12.1")},
+		// CPython is preferred if no implementation is requested.
+		{version: "", want: fakePython("3.11.4")},
+		{version: "3.11", want: fakePython("3.11.4")},
+		{version: "python3.11", want: fakePython("3.11.4")},
+		{version: "python3", want: fakePython("3.12.1")},
+		// The other implementations are used if no CPython matches.
+		{version: "3.10", want: fakePython("pypy-3.10.14")},
+		{version: "3.9.18", want: fakePython("pypy-3.9.18")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := f.Find(context.Background(), tt.version)
+			if err != nil {
+				t.Fatalf("Find(%q) error = %v", tt.version, err)
+			}
+			if got.Path != tt.want {
+				t.Errorf("Find(%q) = %q, want %q", tt.version, got.Path, tt.want)
+			}
+		})
+	}
+
+	if _, err := f.Find(context.Background(), "graalpy3.12"); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Find(%q) error = %v, want %v", "graalpy3.12", err, ErrVersionNotFound)
+	}
+}
+
+func TestFindFreeThreaded(t *testing.T) {
+	f := setupFakeFinder(t,
+		fakeProvider{fakePython("3.13.1t"), fakePython("3.13.0"), fakePython("3.13.0t")},
+	)
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "", want: fakePython("3.13.0")},
+		{version: "3.13", want: fakePython("3.13.0")},
+		{version: "3.13t", want: fakePython("3.13.1t")},
+		{version: "3.13.0t", want: fakePython("3.13.0t")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := f.Find(context.Background(), tt.version)
+			if err != nil {
+				t.Fatalf("Find(%q) error = %v", tt.version, err)
+			}
+			if got.Path != tt.want {
+				t.Errorf("Find(%q) = %q, want %q", tt.version, got.Path, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindPreferOldest(t *testing.T) {
+	f := setupFakeFinder(t,
+		fakeProvider{fakePython("3.11.4"), fakePython("3.10.4")},
+		fakeProvider{fakePython("3.11.1"), fakePython("3.12.1")},
+	)
+	f.preference = PreferOldest
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "3.11", want: fakePython("3.11.1")},
+		{version: ">=3.11", want: fakePython("3.11.1")},
+		{version: ">=3.10,<3.13", want: fakePython("3.10.4")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := f.Find(context.Background(), tt.version)
+			if err != nil {
+				t.Fatalf("Find(%q) error = %v", tt.version, err)
+			}
+			if got.Path != tt.want {
+				t.Errorf("Find(%q) = %q, want %q", tt.version, got.Path, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	want := []string{
+		fakePython("3.12.0"),
+		fakePython("3.8.10"),
+		fakePython("3.11.4"),
+		fakePython("3.10.4"),
+	}
+	f := setupFakeFinder(t,
+		fakeProvider{want[0], want[1]},
+		fakeProvider{want[1], want[2], want[3]},
+	)
+
+	versions, err := f.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	var got []string
+	for _, v := range versions {
+		got = append(got, v.Path)
+	}
+	// The order must be the same as the order of the providers irrespective
+	// of the order in which the executables were probed.
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %q, want %q", got, want)
+	}
+}
+
+func TestFindTrace(t *testing.T) {
+	f := setupFakeFinder(t, fakeProvider{
+		fakePython("3.10.4"),
+		fakePython("3.11.0rc1"),
+		fakePython("3.11.4"),
+		fakePython("3.11.4"),
+	})
+	var got []string
+	f.tracer = func(event TraceEvent) {
+		got = append(got, fmt.Sprintf("%s %s %s", event.Kind, event.Path, event.Reason))
+	}
+
+	if _, err := f.Find(context.Background(), "3.11"); err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	want := []string{
+		"provider  ",
+		"candidate " + fakePython("3.10.4") + " ",
+		"candidate " + fakePython("3.11.0rc1") + " ",
+		"candidate " + fakePython("3.11.4") + " ",
+		"candidate " + fakePython("3.11.4") + " ",
+		"rejected " + fakePython("3.11.4") + " duplicate of " + fakePython("3.11.4"),
+		"probed " + fakePython("3.10.4") + " ",
+		"rejected " + fakePython("3.10.4") + " version 3.10.4 does not match 3.11.*",
+		"probed " + fakePython("3.11.0rc1") + " ",
+		"rejected " + fakePython("3.11.0rc1") + " version 3.11.0rc1 is not a final release",
+		"probed " + fakePython("3.11.4") + " ",
+		"matched " + fakePython("3.11.4") + " ",
+		"selected " + fakePython("3.11.4") + " ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("trace = %q, want %q", got, want)
+	}
+}
+
+func TestCandidatesNotExecutable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the executables are identified by their extension on Windows")
+	}
+
+	dir := t.TempDir()
+	python := writeFakePython(t, dir)
+	notExecutable := filepath.Join(dir, "python3")
+	if err := os.WriteFile(notExecutable, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	execs, err := execsInPath(dir)
+	if err != nil {
+		t.Fatalf("execsInPath(%q) error = %v", dir, err)
+	}
+
+	f := &Finder{providers: []Provider{fakeProvider(execs)}}
+	var rejected []string
+	f.tracer = func(event TraceEvent) {
+		if event.Kind == TraceRejected {
+			rejected = append(rejected, fmt.Sprintf("%s %s", event.Path, event.Reason))
+		}
+	}
+	var got []string
+	for _, c := range f.candidates(context.Background()) {
+		got = append(got, c.path)
+	}
+	if want := []string{resolvePath(t, python)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates() = %q, want %q", got, want)
+	}
+	if want := []string{notExecutable + " not executable"}; !reflect.DeepEqual(rejected, want) {
+		t.Errorf("rejected = %q, want %q", rejected, want)
+	}
+}
+
+// failingProvider is a fakeProvider which also fails to resolve some paths.
+type failingProvider struct {
+	fakeProvider
+	errs providerErrors
+}
+
+func (p failingProvider) Executables() ([]string, error) {
+	return p.fakeProvider, p.errs
+}
+
+func TestCandidatesProviderErrors(t *testing.T) {
+	f := setupFakeFinder(t, failingProvider{
+		fakeProvider: fakeProvider{fakePython("3.11.4")},
+		errs:         providerErrors{{Path: "/bin/pyenv", Err: errors.New("exit status 1")}},
+	})
+	var rejected []string
+	f.tracer = func(event TraceEvent) {
+		if event.Kind == TraceRejected {
+			rejected = append(rejected, fmt.Sprintf("%s %s", event.Path, event.Reason))
+		}
+	}
+
+	if _, err := f.Find(context.Background(), ""); err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	want := []Diagnostic{{Provider: "fake", Path: "/bin/pyenv", Err: errors.New("exit status 1")}}
+	if got := f.Diagnostics(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagnostics() = %v, want %v", got, want)
+	}
+	if want := []string{"/bin/pyenv exit status 1"}; !reflect.DeepEqual(rejected, want) {
+		t.Errorf("rejected = %q, want %q", rejected, want)
+	}
+}
+
+// namedProvider is a fakeProvider with the given name.
+type namedProvider struct {
+	fakeProvider
+	name string
+}
+
+func (p namedProvider) Name() string {
+	return p.name
+}
+
+func TestCandidatesAliases(t *testing.T) {
+	dir := t.TempDir()
+	python := writeFakePython(t, filepath.Join(dir, "python", binDir))
+	alias := filepath.Join(dir, "alias")
+	if err := os.Symlink(python, alias); err != nil {
+		t.Skipf("Symlink() error = %v", err)
+	}
+	other := writeFakePython(t, filepath.Join(dir, "other", binDir))
+
+	f := &Finder{
+		providers: []Provider{
+			namedProvider{fakeProvider{alias, other}, "first"},
+			namedProvider{fakeProvider{python, alias}, "second"},
+		},
+	}
+	got := f.candidates(context.Background())
+	want := []candidate{
+		{
+			provider:  f.providers[0],
+			path:      resolvePath(t, python),
+			aliases:   []string{alias, python},
+			providers: []string{"first", "second"},
+		},
+		{
+			provider:  f.providers[0],
+			path:      resolvePath(t, other),
+			aliases:   []string{other},
+			providers: []string{"first"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFindDiagnostics(t *testing.T) {
+	f := setupFakeFinder(t, fakeProvider{
+		fakePython("fail"),
+		fakePython("garbage"),
+		fakePython("2.7.18"),
+		fakePython("3.11.4"),
+	})
+
+	versions, err := f.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0].Path != fakePython("3.11.4") {
+		t.Errorf("FindAll() = %v, want only %q", versions, fakePython("3.11.4"))
+	}
+
+	// Python 2 is excluded, but it's not a failure.
+	var got []string
+	for _, d := range f.Diagnostics() {
+		got = append(got, d.Path)
+	}
+	if want := []string{fakePython("fail"), fakePython("garbage")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagnostics() = %q, want %q", got, want)
+	}
+	if d := f.Diagnostics()[0]; !strings.Contains(d.Err.Error(), "command not found") {
+		t.Errorf("Diagnostics()[0].Err = %q, want the output of the executable", d.Err)
+	}
+}
+
+func TestFindProbeTimeout(t *testing.T) {
+	f := setupFakeFinder(t, fakeProvider{fakePython("hang"), fakePython("3.11.4")})
+	f.probeTimeout = 2 * time.Second
+
+	start := time.Now()
+	got, err := f.Find(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if want := fakePython("3.11.4"); got.Path != want {
+		t.Errorf("Find() = %q, want %q", got.Path, want)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Find() took %s, want the hanging probe to time out", elapsed)
+	}
+}
+
+func TestFindContext(t *testing.T) {
+	f := setupFakeFinder(t, fakeProvider{fakePython("hang"), fakePython("3.11.4")})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := f.Find(ctx, "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Find() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("Find() took %s, want it to stop with the context", elapsed)
+	}
+}
+
+func TestFindFilter(t *testing.T) {
+	f := setupFakeFinder(t, fakeProvider{
+		fakePython("pypy-3.11.4"),
+		fakePython("3.11.2"),
+		fakePython("3.10.4"),
+	})
+	f.filters = append(f.filters, func(pythonExecutable *PythonExecutable) error {
+		if pythonExecutable.Implementation != "cpython" {
+			return fmt.Errorf("implementation %s is not allowed", pythonExecutable.Implementation)
+		}
+		return nil
+	})
+
+	got, err := f.Find(context.Background(), "3")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if want := fakePython("3.11.2"); got.Path != want {
+		t.Errorf("Find() = %q, want %q", got.Path, want)
+	}
+}
+
+func TestFindPrereleases(t *testing.T) {
+	providers := []Provider{
+		fakeProvider{fakePython("3.13.1"), fakePython("3.14.0b2"), fakePython("3.14.0b3"), fakePython("3.15.0a1")},
+	}
+
+	tests := []struct {
+		version          string
+		allowPrereleases bool
+		want             string
+	}{
+		{version: "3.14", allowPrereleases: true, want: fakePython("3.14.0b3")},
+		{version: "3.14.0b2", want: fakePython("3.14.0b2")},
+		// A final release is preferred over a pre-release.
+		{version: "3", allowPrereleases: true, want: fakePython("3.13.1")},
+		{version: ">=3.13", want: fakePython("3.13.1")},
+		{version: ">=3.14", allowPrereleases: true, want: fakePython("3.15.0a1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			f := setupFakeFinder(t, providers...)
+			f.allowPrereleases = tt.allowPrereleases
+			got, err := f.Find(context.Background(), tt.version)
+			if err != nil {
+				t.Fatalf("Find(%q) error = %v", tt.version, err)
+			}
+			if got.Path != tt.want {
+				t.Errorf("Find(%q) = %q, want %q", tt.version, got.Path, tt.want)
+			}
+		})
+	}
+
+	// The pre-releases are not used without the option, even if no final
+	// release matches.
+	f := setupFakeFinder(t, providers...)
+	for _, version := range []string{"3.14", ">=3.14"} {
+		if _, err := f.Find(context.Background(), version); err != ErrVersionNotFound {
+			t.Errorf("Find(%q) error = %v, want %v", version, err, ErrVersionNotFound)
+		}
+	}
+}
+
+func TestFindCanCreateVirtualenv(t *testing.T) {
+	noEnsurepip := filepath.Join(string(filepath.Separator), "noensurepip", "3.11.4", "python")
+	f := setupFakeFinder(t, fakeProvider{noEnsurepip, fakePython("3.11.2")})
+	f.filters = append(f.filters, CanCreateVirtualenv)
+
+	got, err := f.Find(context.Background(), "3.11")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if want := fakePython("3.11.2"); got.Path != want {
+		t.Errorf("Find() = %q, want %q", got.Path, want)
+	}
+
+	_, err = f.Find(context.Background(), "3.11.4")
+	var rejectedErr *RejectedError
+	if !errors.As(err, &rejectedErr) {
+		t.Fatalf("Find() error = %v, want a RejectedError", err)
+	}
+	if !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Find() error = %v, want it to wrap %v", err, ErrVersionNotFound)
+	}
+	want := []Diagnostic{{Provider: "fake", Path: noEnsurepip, Err: ErrNoEnsurepip}}
+	if !reflect.DeepEqual(rejectedErr.Rejected, want) {
+		t.Errorf("Find() rejected = %v, want %v", rejectedErr.Rejected, want)
+	}
+
+	// The executables which do not match are not reported as rejected.
+	if _, err := f.Find(context.Background(), "3.12"); err != ErrVersionNotFound {
+		t.Errorf("Find() error = %v, want %v", err, ErrVersionNotFound)
+	}
+}
+
+func TestFindPath(t *testing.T) {
+	f := setupFakeFinder(t)
+
+	got, err := f.Find(context.Background(), fakePython("3.11.4"))
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if want := fakePython("3.11.4"); got.Path != want {
+		t.Errorf("Find() = %q, want %q", got.Path, want)
+	}
+
+	if _, err := f.Find(context.Background(), fakePython("2.7.18")); err == nil {
+		t.Errorf("Find() error = nil, want Python 2 to be rejected")
+	}
+
+	evalSymlinks = filepath.EvalSymlinks
+	missing := filepath.Join(t.TempDir(), "python")
+	if _, err := f.Find(context.Background(), missing); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Find(%q) error = %v, want %v", missing, err, ErrVersionNotFound)
+	}
+}
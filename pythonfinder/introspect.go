@@ -0,0 +1,107 @@
+package pythonfinder
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	pep440Version "github.com/aquasecurity/go-pep440-version"
+)
+
+var execCommandContext = exec.CommandContext
+
+// introspectScript is the Python script run by the Python executable being
+// probed, which reports the interpreterInfo as a JSON object.
+//
+//go:embed introspect.py
+var introspectScript string
+
+// interpreterInfo is the information reported by the introspection script
+// for a Python executable.
+type interpreterInfo struct {
+	Version        string `json:"version"`
+	Implementation string `json:"implementation"`
+	Machine        string `json:"machine"`
+	PointerSize    int    `json:"pointer_size"`
+	Prefix         string `json:"prefix"`
+	BasePrefix     string `json:"base_prefix"`
+	IsVirtualenv   bool   `json:"is_virtualenv"`
+	ABIFlags       string `json:"abiflags"`
+	FreeThreaded   bool   `json:"free_threaded"`
+	HasVenv        bool   `json:"has_venv"`
+	HasEnsurepip   bool   `json:"has_ensurepip"`
+}
+
+// pythonExecutable returns the PythonExecutable for the given executable path
+// using the introspected information.
+func (info *interpreterInfo) pythonExecutable(executable string) (*PythonExecutable, error) {
+	versionInfo, err := pep440Version.Parse(info.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &PythonExecutable{
+		Version:        &versionInfo,
+		Path:           executable,
+		Implementation: info.Implementation,
+		Arch:           info.Machine,
+		PointerSize:    info.PointerSize,
+		BasePrefix:     info.BasePrefix,
+		IsVirtualenv:   info.IsVirtualenv,
+		ABIFlags:       info.ABIFlags,
+		FreeThreaded:   info.FreeThreaded,
+		HasVenv:        info.HasVenv,
+		HasEnsurepip:   info.HasEnsurepip,
+	}, nil
+}
+
+// introspect runs the introspection script using the given Python executable
+// and returns the reported information.
+//
+// If the context is done before the executable responds, the context error
+// is returned. The executable is killed, but the function does not wait for
+// it to exit as a process it spawned might still hold the output open.
+func introspect(ctx context.Context, executable string) (*interpreterInfo, error) {
+	// The environment variables and the user site directory are ignored as
+	// they could change the reported information. The command does not
+	// inherit the standard input, so a script waiting for input gets an EOF
+	// instead of blocking.
+	cmd := execCommandContext(ctx, executable, "-E", "-s", "-c", introspectScript)
+	// The modules in the current directory, like a "platform.py" in the
+	// project, could shadow the ones imported by the script, which also
+	// removes the current directory from the module search path.
+	cmd.Dir = os.TempDir()
+
+	type commandResult struct {
+		output []byte
+		err    error
+	}
+	done := make(chan commandResult, 1)
+	go func() {
+		output, err := cmd.Output()
+		done <- commandResult{output: output, err: err}
+	}()
+
+	var output []byte
+	select {
+	case result := <-done:
+		if result.err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, result.err
+		}
+		output = result.output
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var info interpreterInfo
+	if err := json.Unmarshal(output, &info); err != nil || info.Version == "" {
+		return nil, fmt.Errorf("Unable to parse Python information: %q", strings.TrimSpace(string(output)))
+	}
+	return &info, nil
+}
@@ -0,0 +1,70 @@
+# This script is run by pie using the Python interpreter being probed to
+# report information about it as a JSON object on stdout.
+#
+# It must be compatible with all the Python versions, including Python 2, so
+# that it can at least report the version of such interpreters.
+import sys
+
+# The current directory is the first entry of the module search path when
+# running with "-c", so a module like "platform.py" in a project would be
+# imported instead of the standard library one.
+if sys.path and sys.path[0] == "":
+    del sys.path[0]
+
+import json
+import platform
+import struct
+
+try:
+    import sysconfig
+except ImportError:
+    sysconfig = None
+
+
+def has_module(name):
+    try:
+        from importlib.util import find_spec
+    except ImportError:
+        import imp
+
+        try:
+            imp.find_module(name)
+        except ImportError:
+            return False
+        return True
+    try:
+        return find_spec(name) is not None
+    except (ImportError, ValueError):
+        return False
+
+
+def config_var(name):
+    if sysconfig is None:
+        return None
+    return sysconfig.get_config_var(name)
+
+
+implementation = getattr(sys, "implementation", None)
+if implementation is not None:
+    implementation_name = implementation.name
+else:
+    implementation_name = platform.python_implementation().lower()
+
+base_prefix = getattr(sys, "base_prefix", getattr(sys, "real_prefix", sys.prefix))
+
+json.dump(
+    {
+        "version": platform.python_version(),
+        "implementation": implementation_name,
+        "machine": platform.machine(),
+        "pointer_size": struct.calcsize("P") * 8,
+        "prefix": sys.prefix,
+        "base_prefix": base_prefix,
+        "is_virtualenv": sys.prefix != base_prefix,
+        "abiflags": getattr(sys, "abiflags", ""),
+        "free_threaded": config_var("Py_GIL_DISABLED") == 1,
+        "has_venv": has_module("venv"),
+        "has_ensurepip": has_module("ensurepip"),
+    },
+    sys.stdout,
+)
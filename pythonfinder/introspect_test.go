@@ -0,0 +1,191 @@
+package pythonfinder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fake exec command helper
+var testCaseName string
+
+func TestIntrospect(t *testing.T) {
+	// Setup fake exec command
+	testCaseName = "TestIntrospect"
+	execCommandContext = fakeExecCommandContext
+	defer func() {
+		execCommandContext = exec.CommandContext
+	}()
+
+	executable := "/bin/python"
+	info, err := introspect(context.Background(), executable)
+	if err != nil {
+		t.Fatalf("introspect(%q) unexpected error = %q", executable, err)
+	}
+	got, err := info.pythonExecutable(executable)
+	if err != nil {
+		t.Fatalf("pythonExecutable(%q) unexpected error = %q", executable, err)
+	}
+
+	if want := "3.11.0"; got.Version.String() != want {
+		t.Errorf("introspect(%q).Version = %q, want %q", executable, got.Version, want)
+	}
+	got.Version = nil
+	want := &PythonExecutable{
+		Path:           executable,
+		Implementation: "cpython",
+		Arch:           "x86_64",
+		PointerSize:    64,
+		BasePrefix:     "/usr",
+		HasVenv:        true,
+		HasEnsurepip:   true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("introspect(%q) = %+v, want %+v", executable, got, want)
+	}
+}
+
+func TestIntrospectInvalidOutput(t *testing.T) {
+	testCaseName = "TestIntrospectInvalidOutput"
+	execCommandContext = fakeExecCommandContext
+	defer func() {
+		execCommandContext = exec.CommandContext
+	}()
+
+	executable := "/bin/python"
+	if _, err := introspect(context.Background(), executable); err == nil {
+		t.Errorf("introspect(%q) error = nil, want non-nil", executable)
+	}
+}
+
+func TestIntrospectShadowedModules(t *testing.T) {
+	executable, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not found in PATH")
+	}
+
+	// The modules in the current directory must not shadow the ones imported
+	// by the introspection script.
+	dir := t.TempDir()
+	for _, name := range []string{"platform.py", "json.py", "struct.py"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("raise SystemExit(1)\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if _, err := introspect(context.Background(), executable); err != nil {
+		t.Errorf("introspect(%q) error = %v, want nil", executable, err)
+	}
+}
+
+func fakeExecCommandContext(ctx context.Context, name string, arg ...string) *exec.Cmd {
+	cs := []string{"-test.run=TestHelperProcess", "--", name}
+	cs = append(cs, arg...)
+	cmd := exec.CommandContext(ctx, os.Args[0], cs...)
+	cmd.Env = []string{"GO_WANT_HELPER_PROCESS=1", "GO_TEST_CASE_NAME=" + testCaseName}
+	return cmd
+}
+
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	cmd, args := os.Args[3], os.Args[4:]
+
+	if os.Getenv("GO_TEST_CASE_NAME") == "TestPathProviderShims" {
+		// The version manager, e.g., "<root>/bin/pyenv prefix", which selects
+		// a Python version which is not installed and an installed one.
+		if len(args) != 1 || args[0] != "prefix" {
+			fmt.Fprintf(os.Stderr, "pyenv: no such command %q", args)
+			os.Exit(1)
+		}
+		root := filepath.Dir(filepath.Dir(cmd))
+		fmt.Fprintln(os.Stdout, strings.Join([]string{
+			filepath.Join(root, "versions", "2.7.18"),
+			filepath.Join(root, "versions", "3.11.7"),
+		}, string(os.PathListSeparator)))
+		os.Exit(0)
+	}
+
+	if !strings.HasSuffix(cmd, "python") {
+		fmt.Fprintf(os.Stderr, "command not found: %q", cmd)
+		os.Exit(1)
+	}
+
+	if len(args) != 4 || args[2] != "-c" || args[3] != introspectScript {
+		fmt.Fprintf(os.Stderr, "invalid arguments: %q", args)
+		os.Exit(1)
+	}
+
+	info := interpreterInfo{
+		Version:        "3.11.0",
+		Implementation: "cpython",
+		Machine:        "x86_64",
+		PointerSize:    64,
+		Prefix:         "/usr",
+		BasePrefix:     "/usr",
+		HasVenv:        true,
+		HasEnsurepip:   true,
+	}
+
+	switch os.Getenv("GO_TEST_CASE_NAME") {
+	case "TestIntrospect":
+	case "TestIntrospectInvalidOutput":
+		fmt.Fprintln(os.Stdout, "Python 3.11.0")
+		os.Exit(0)
+	case "TestFind":
+		// The version is the name of the directory containing the
+		// executable, e.g., "/3.11.0/python", optionally prefixed by the
+		// implementation, e.g., "/pypy-3.10.14/python", or suffixed by "t"
+		// for a free-threaded build, unless the executable is supposed to
+		// hang, fail or output garbage. The "ensurepip" module is missing
+		// if the directory is inside "noensurepip", e.g.,
+		// "/noensurepip/3.11.0/python".
+		info.Version = filepath.Base(filepath.Dir(cmd))
+		if filepath.Base(filepath.Dir(filepath.Dir(cmd))) == "noensurepip" {
+			info.HasEnsurepip = false
+		}
+		switch info.Version {
+		case "hang":
+			time.Sleep(time.Minute)
+		case "fail":
+			fmt.Fprintln(os.Stderr, "pyenv: python: command not found")
+			os.Exit(127)
+		case "garbage":
+			fmt.Fprintln(os.Stdout, "Python 2.7.18")
+			os.Exit(0)
+		}
+		if implementation, version, found := strings.Cut(info.Version, "-"); found {
+			info.Implementation, info.Version = implementation, version
+		}
+		if strings.HasSuffix(info.Version, "t") {
+			info.Version = strings.TrimSuffix(info.Version, "t")
+			info.FreeThreaded = true
+		}
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(info); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
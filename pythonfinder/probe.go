@@ -0,0 +1,124 @@
+package pythonfinder
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// candidate is a Python executable found by the providers which is yet to be
+// probed.
+type candidate struct {
+	// provider is the first provider which found the executable.
+	provider Provider
+
+	// path is the absolute, resolved path to the executable.
+	path string
+
+	// aliases are the paths through which the providers found the
+	// executable, in the order they were found.
+	aliases []string
+
+	// providers are the names of all the providers which found the
+	// executable, in the order they're consulted.
+	providers []string
+}
+
+// probeResult is the result of probing a single candidate.
+type probeResult struct {
+	pythonExecutable *PythonExecutable
+	err              error
+}
+
+// probeAll probes the given candidates concurrently using a bounded pool of
+// workers, each probe being limited by the probe timeout of the finder.
+//
+// The result for the candidate at index i is sent on the channel at index i
+// of the returned slice, which allows the caller to consume the results in
+// the same order as the candidates. This is how the provider priority is
+// preserved even though the probes complete in any order.
+//
+// The caller can stop the probing early by canceling the given context, after
+// which the results for the remaining candidates might never be sent. The
+// returned function blocks until all the workers have stopped and must be
+// called before the results are discarded.
+func (f *Finder) probeAll(ctx context.Context, c *cache, candidates []candidate) ([]chan probeResult, func()) {
+	results := make([]chan probeResult, len(candidates))
+	for i := range results {
+		results[i] = make(chan probeResult, 1)
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range candidates {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	workers := f.concurrency
+	if workers > len(candidates) {
+		workers = len(candidates)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				pythonExecutable, err := f.probe(ctx, c, candidates[i].path)
+				results[i] <- probeResult{pythonExecutable: pythonExecutable, err: err}
+			}
+		}()
+	}
+
+	return results, wg.Wait
+}
+
+// probe returns the PythonExecutable for the given executable path, using the
+// cached information if it's still valid. The cache is updated with the
+// probed information otherwise. The cache can be nil, in which case the
+// executable is always probed.
+//
+// The executable is given at most the probe timeout of the finder to respond,
+// after which it's killed and the context error is returned.
+func (f *Finder) probe(ctx context.Context, c *cache, executable string) (*PythonExecutable, error) {
+	var info *interpreterInfo
+	var stat fileStat
+
+	if c != nil {
+		fileInfo, err := os.Stat(executable)
+		if err != nil {
+			return nil, err
+		}
+		stat = newFileStat(fileInfo)
+		if !f.refresh {
+			info, _ = c.get(executable, stat)
+		}
+	}
+
+	if info == nil {
+		ctx, cancel := context.WithTimeout(ctx, f.probeTimeout)
+		defer cancel()
+
+		var err error
+		info, err = introspect(ctx, executable)
+		if err != nil {
+			return nil, err
+		}
+		// The modules which can be installed separately, like "ensurepip"
+		// by the "python3-venv" package on Debian, do not change the
+		// executable. So, the information is only cached once they're
+		// available, for the installation to be noticed.
+		if c != nil && info.HasVenv && info.HasEnsurepip {
+			c.put(executable, info, stat)
+		}
+	}
+
+	return info.pythonExecutable(executable)
+}
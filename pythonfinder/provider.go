@@ -0,0 +1,380 @@
+package pythonfinder
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// Provider is an interface that provides Python executables.
+//
+// Each Provider has contextual information that is used to find Python
+// executables.
+type Provider interface {
+	// Name returns the name of the provider, which is used to enable and
+	// order the providers.
+	Name() string
+
+	// Executables returns a list of absolute paths to Python executables.
+	// The paths should not be resolved, as the symlinks pointing to the same
+	// executable are grouped together by the finder.
+	Executables() ([]string, error)
+}
+
+// providerFactory creates a Provider with the given name.
+type providerFactory struct {
+	name string
+
+	// new returns a new Provider for the given Finder, or nil if the
+	// provider is not available on the system.
+	new func(f *Finder) Provider
+}
+
+// providerFactories are the factories of all the providers in the default
+// order in which they're consulted.
+var providerFactories = []providerFactory{
+	{"path", func(*Finder) Provider {
+		return newPathProvider()
+	}},
+	{"search-paths", func(f *Finder) Provider {
+		if p := newSearchPathsProvider(f.searchPaths); p != nil {
+			return p
+		}
+		return nil
+	}},
+	{"macos", func(*Finder) Provider {
+		if runtime.GOOS == "darwin" {
+			if p := newMacOSProvider(); p != nil {
+				return p
+			}
+		}
+		return nil
+	}},
+	{"registry", func(*Finder) Provider {
+		if reader := systemRegistry(); reader != nil {
+			return newRegistryProvider(reader)
+		}
+		return nil
+	}},
+	{"pyenv", func(*Finder) Provider {
+		if runtime.GOOS != "windows" {
+			if p := newPyenvProvider(); p != nil {
+				return p
+			}
+		}
+		return nil
+	}},
+	{"asdf", func(*Finder) Provider {
+		if runtime.GOOS != "windows" {
+			if p := newAsdfProvider(); p != nil {
+				return p
+			}
+		}
+		return nil
+	}},
+	{"homebrew", func(*Finder) Provider {
+		if runtime.GOOS != "windows" {
+			if p := newHomebrewProvider(); p != nil {
+				return p
+			}
+		}
+		return nil
+	}},
+	{"mise", func(*Finder) Provider {
+		if p := newMiseProvider(); p != nil {
+			return p
+		}
+		return nil
+	}},
+	{"conda", func(*Finder) Provider {
+		if p := newCondaProvider(); p != nil {
+			return p
+		}
+		return nil
+	}},
+	{"uv", func(*Finder) Provider {
+		if p := newUvProvider(); p != nil {
+			return p
+		}
+		return nil
+	}},
+	{"rye", func(*Finder) Provider {
+		if p := newRyeProvider(); p != nil {
+			return p
+		}
+		return nil
+	}},
+	{"hatch", func(*Finder) Provider {
+		if p := newHatchProvider(); p != nil {
+			return p
+		}
+		return nil
+	}},
+	{"pdm", func(*Finder) Provider {
+		if p := newPdmProvider(); p != nil {
+			return p
+		}
+		return nil
+	}},
+}
+
+// providersMu guards providerFactories against the concurrent registrations.
+var providersMu sync.RWMutex
+
+// Register makes a third-party provider available under the given name. The
+// registered providers are consulted after the builtin ones, in the order
+// they were registered, and can be enabled and ordered the same way using
+// WithProviders.
+//
+// The given function returns a new Provider, or nil if the provider is not
+// available on the system. It's called once for each Finder.
+//
+// Register is meant to be called from an init function. It panics if the name
+// is not in lowercase, contains a comma, starts with "-" or is already
+// registered.
+func Register(name string, newProvider func() Provider) {
+	if name == "" || name != strings.ToLower(strings.TrimSpace(name)) ||
+		strings.Contains(name, ",") || strings.HasPrefix(name, "-") {
+		panic(fmt.Sprintf("pythonfinder: invalid provider name %q", name))
+	}
+	if newProvider == nil {
+		panic("pythonfinder: Register provider is nil")
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	for _, factory := range providerFactories {
+		if factory.name == name {
+			panic(fmt.Sprintf("pythonfinder: Register called twice for provider %q", name))
+		}
+	}
+	providerFactories = append(providerFactories, providerFactory{
+		name: name,
+		new: func(*Finder) Provider {
+			return newProvider()
+		},
+	})
+}
+
+// factories returns a snapshot of the provider factories, including the
+// registered ones.
+func factories() []providerFactory {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return providerFactories[:len(providerFactories):len(providerFactories)]
+}
+
+// ProviderNames returns the names of all the providers, including the
+// registered ones, in the default order in which they're consulted.
+func ProviderNames() []string {
+	factories := factories()
+	names := make([]string, 0, len(factories))
+	for _, factory := range factories {
+		names = append(names, factory.name)
+	}
+	return names
+}
+
+// ParseProviders returns the names of the enabled providers in the order
+// they should be consulted as per the given list.
+//
+// If the list contains any provider name without the "-" prefix, only those
+// providers are enabled in the given order. Otherwise, all the providers are
+// enabled in the default order except the ones with the "-" prefix. For
+// example, "pyenv,path" only enables the pyenv and PATH providers in that
+// order, while "-path" enables all the providers except the PATH provider.
+//
+// An error is returned if the list contains an unknown provider name.
+func ParseProviders(list []string) ([]string, error) {
+	factories := factories()
+	known := make(map[string]struct{}, len(factories))
+	for _, factory := range factories {
+		known[factory.name] = struct{}{}
+	}
+
+	var enabled []string
+	disabled := make(map[string]struct{})
+	for _, name := range list {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		disable := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown provider %q: must be one of %s", name, strings.Join(ProviderNames(), ", "))
+		}
+		if disable {
+			disabled[name] = struct{}{}
+		} else {
+			enabled = append(enabled, name)
+		}
+	}
+	if enabled == nil {
+		enabled = ProviderNames()
+	}
+
+	names := make([]string, 0, len(enabled))
+	seen := make(map[string]struct{}, len(enabled))
+	for _, name := range enabled {
+		if _, ok := disabled[name]; ok {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// contextProvider is an optional interface implemented by a Provider which
+// executes other programs to find the Python executables, so that they are
+// stopped when the search is cancelled.
+type contextProvider interface {
+	// executablesContext is like Executables, but with a context.
+	executablesContext(ctx context.Context) ([]string, error)
+}
+
+// annotator is an optional interface implemented by a Provider which knows
+// additional information about the Python executables it provides, without
+// having to execute them.
+type annotator interface {
+	// annotate fills in the information known by the provider for the given
+	// Python executable, which was returned by the provider.
+	annotate(pythonExecutable *PythonExecutable)
+}
+
+// execsInPath returns a list of Python executables in the given path.
+// The returned paths are absolute, but the symlinks are not resolved.
+//
+// The files which are not executable are included, as they are skipped by
+// the finder so that the reason can be traced.
+//
+// The given path should be an absolute path to a directory. If it's not
+// a directory, the function will not proceed and return a nil slice.
+//
+// This is a helper function for Provider implementations.
+func execsInPath(path string) ([]string, error) {
+	if !pathutil.IsDir(path) {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var execs []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || !looksLikePython(entry.Name()) {
+			continue
+		}
+
+		execPath := filepath.Join(path, entry.Name())
+		if _, err := os.Stat(execPath); err != nil {
+			// This is usually a broken symlink, which is not worth failing
+			// the whole directory for.
+			continue
+		}
+		execs = append(execs, execPath)
+	}
+
+	return execs, nil
+}
+
+// execsInSubdirs returns a list of Python executables in each subdirectory
+// of the given directory. The executables are searched for in the given bin
+// directories, relative to the subdirectory, which usually depends on the
+// layout of the installation. Hidden subdirectories are skipped.
+//
+// If the given directory does not exist, the function will not proceed and
+// return a nil slice.
+//
+// This is a helper function for Provider implementations of tools which
+// install each Python version in its own directory.
+func execsInSubdirs(dir string, binDirs ...string) ([]string, error) {
+	if !pathutil.IsDir(dir) {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var executables []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		for _, binDir := range binDirs {
+			execs, err := execsInPath(filepath.Join(dir, entry.Name(), binDir))
+			if err != nil {
+				return nil, err
+			}
+			executables = append(executables, execs...)
+		}
+	}
+
+	return executables, nil
+}
+
+// userDataDir returns the directory where the given tool stores its data as
+// per the XDG base directory specification, which is followed by most Python
+// tools on all the platforms except Windows.
+func userDataDir(tool string) (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, tool), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".local", "share", tool), nil
+}
+
+// platformDataDir returns the directory where the given tool stores its data
+// as per the platform conventions. This is the same directory as returned by
+// the "platformdirs" Python package, which is used by tools like hatch and pdm.
+func platformDataDir(tool string) (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, tool), nil
+		}
+	case "darwin":
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(homeDir, "Library", "Application Support", tool), nil
+	}
+	return userDataDir(tool)
+}
+
+// looksLikePython returns true if the given filename looks like a Python
+// executable.
+func looksLikePython(name string) bool {
+	return pythonFileRegex.MatchString(name)
+}
+
+// isExecutable returns true if the given file info is executable.
+// On Windows, it just checks if the file extension is ".exe" or not.
+func isExecutable(info fs.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		return strings.ToLower(filepath.Ext(info.Name())) == ".exe"
+	}
+	return info.Mode().IsRegular() && info.Mode()&0o111 != 0
+}
@@ -20,20 +20,30 @@ type asdfProvider struct {
 // the environment variable ASDF_DATA_DIR, fallback to the default asdf
 // installation directory.
 func newAsdfProvider() *asdfProvider {
-	root := os.Getenv("ASDF_DATA_DIR")
-	if root == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil
-		}
-		root = filepath.Join(homeDir, ".asdf")
-	}
-	if !pathutil.IsDir(root) {
+	root, err := asdfRoot()
+	if err != nil || !pathutil.IsDir(root) {
 		return nil
 	}
 	return &asdfProvider{root: root}
 }
 
+// asdfRoot returns the data directory of the asdf installation, which is the
+// ASDF_DATA_DIR environment variable if set, or the default one.
+func asdfRoot() (string, error) {
+	if root := os.Getenv("ASDF_DATA_DIR"); root != "" {
+		return root, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".asdf"), nil
+}
+
+func (p *asdfProvider) Name() string {
+	return "asdf"
+}
+
 func (p *asdfProvider) Executables() ([]string, error) {
 	pythonDir := filepath.Join(p.root, "installs", "python")
 	if !pathutil.IsDir(pythonDir) {
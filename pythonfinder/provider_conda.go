@@ -0,0 +1,169 @@
+package pythonfinder
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// condaRootNames are the directory names, relative to the user's home
+// directory, where the conda family of installers put the base installation
+// by default.
+var condaRootNames = []string{
+	"miniconda3",
+	"miniconda",
+	"anaconda3",
+	"anaconda",
+	"miniforge3",
+	"mambaforge",
+}
+
+// condaSystemRoots are the system-wide directories where conda is commonly
+// installed, mostly seen in Docker images and shared machines.
+var condaSystemRoots = []string{
+	"/opt/conda",
+	"/opt/miniconda3",
+	"/opt/miniforge3",
+	"/opt/mambaforge",
+}
+
+// condaProvider is a Provider that finds Python executables in the conda
+// installations, including the base environment and all the named
+// environments.
+type condaProvider struct {
+	// roots are the root directories of the conda installations. The base
+	// environment lives directly in the root directory while the named
+	// environments live in the "envs" subdirectory.
+	roots []string
+
+	// envs are the environment prefixes which were found outside of the
+	// "envs" subdirectory of any root.
+	envs []string
+}
+
+// newCondaProvider returns a new condaProvider.
+//
+// It will return nil if conda is not installed. The installations are
+// deduced by checking the following, in order:
+//  1. The active environment using the environment variable CONDA_PREFIX.
+//  2. The installation containing the executable CONDA_EXE points to.
+//  3. The environments registered in "~/.conda/environments.txt".
+//  4. The common installation directories for miniconda, anaconda,
+//     miniforge and mambaforge.
+func newCondaProvider() *condaProvider {
+	var roots, envs []string
+
+	if prefix := os.Getenv("CONDA_PREFIX"); prefix != "" {
+		envs = append(envs, prefix)
+	}
+	if exe := os.Getenv("CONDA_EXE"); exe != "" {
+		// CONDA_EXE is either "<root>/bin/conda" or "<root>\Scripts\conda.exe".
+		roots = append(roots, filepath.Dir(filepath.Dir(exe)))
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
+		registered, err := readCondaEnvironments(filepath.Join(homeDir, ".conda", "environments.txt"))
+		if err == nil {
+			envs = append(envs, registered...)
+		}
+		for _, name := range condaRootNames {
+			roots = append(roots, filepath.Join(homeDir, name))
+		}
+	}
+	if runtime.GOOS != "windows" {
+		roots = append(roots, condaSystemRoots...)
+	}
+
+	p := &condaProvider{}
+	seen := make(map[string]struct{})
+	for _, root := range roots {
+		if _, ok := seen[root]; ok || !pathutil.IsDir(filepath.Join(root, "conda-meta")) {
+			continue
+		}
+		seen[root] = struct{}{}
+		p.roots = append(p.roots, root)
+	}
+	for _, env := range envs {
+		if _, ok := seen[env]; ok || !pathutil.IsDir(env) {
+			continue
+		}
+		// Named environments of a known root will be found through the root.
+		if parent := filepath.Dir(env); filepath.Base(parent) == "envs" {
+			if _, ok := seen[filepath.Dir(parent)]; ok {
+				continue
+			}
+		}
+		seen[env] = struct{}{}
+		p.envs = append(p.envs, env)
+	}
+
+	if len(p.roots) == 0 && len(p.envs) == 0 {
+		return nil
+	}
+	return p
+}
+
+func (p *condaProvider) Name() string {
+	return "conda"
+}
+
+func (p *condaProvider) Executables() ([]string, error) {
+	prefixes := make([]string, 0, len(p.roots)+len(p.envs))
+
+	for _, root := range p.roots {
+		prefixes = append(prefixes, root)
+
+		envsDir := filepath.Join(root, "envs")
+		if !pathutil.IsDir(envsDir) {
+			continue
+		}
+		entries, err := os.ReadDir(envsDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				prefixes = append(prefixes, filepath.Join(envsDir, entry.Name()))
+			}
+		}
+	}
+	prefixes = append(prefixes, p.envs...)
+
+	var executables []string
+
+	for _, prefix := range prefixes {
+		execs, err := execsInPath(filepath.Join(prefix, binDir))
+		if err != nil {
+			return nil, err
+		}
+		executables = append(executables, execs...)
+	}
+
+	return executables, nil
+}
+
+// readCondaEnvironments reads the environments file maintained by conda,
+// which contains the absolute path to an environment prefix on each line.
+func readCondaEnvironments(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var envs []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		envs = append(envs, line)
+	}
+	return envs, scanner.Err()
+}
@@ -0,0 +1,45 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// hatchProvider is a Provider that finds Python executables installed by
+// hatch using `hatch python install`.
+type hatchProvider struct {
+	// root is the data directory of the hatch installation.
+	root string
+}
+
+// newHatchProvider returns a new hatchProvider.
+//
+// It will return nil if hatch is not installed. This is deduced by checking
+// the environment variable HATCH_DATA_DIR, fallback to the default hatch
+// data directory.
+func newHatchProvider() *hatchProvider {
+	root := os.Getenv("HATCH_DATA_DIR")
+	if root == "" {
+		var err error
+		root, err = platformDataDir("hatch")
+		if err != nil {
+			return nil
+		}
+	}
+	if !pathutil.IsDir(root) {
+		return nil
+	}
+	return &hatchProvider{root: root}
+}
+
+func (p *hatchProvider) Name() string {
+	return "hatch"
+}
+
+func (p *hatchProvider) Executables() ([]string, error) {
+	// Each distribution is extracted in a "python" subdirectory, for
+	// example, "pythons/3.12/python/bin/python3".
+	return execsInSubdirs(filepath.Join(p.root, "pythons"), filepath.Join("python", binDir))
+}
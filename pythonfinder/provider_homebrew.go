@@ -0,0 +1,75 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// homebrewPrefixes are the default Homebrew installation prefixes on macOS
+// (Apple Silicon and Intel) and Linux.
+var homebrewPrefixes = []string{
+	"/opt/homebrew",
+	"/usr/local",
+	"/home/linuxbrew/.linuxbrew",
+}
+
+// homebrewProvider is a Provider that finds Python executables installed
+// by Homebrew or Linuxbrew, including the keg-only and unlinked formulas
+// which are not available in PATH.
+type homebrewProvider struct {
+	// prefix is the Homebrew installation prefix.
+	prefix string
+}
+
+// newHomebrewProvider returns a new homebrewProvider.
+//
+// It will return nil if Homebrew is not installed. This is deduced by
+// checking the environment variable HOMEBREW_PREFIX, fallback to the
+// default Homebrew installation prefixes.
+func newHomebrewProvider() *homebrewProvider {
+	var prefixes []string
+	if prefix := os.Getenv("HOMEBREW_PREFIX"); prefix != "" {
+		prefixes = []string{prefix}
+	} else {
+		// The defaults are copied so that they are not modified by append.
+		prefixes = append(prefixes, homebrewPrefixes...)
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			prefixes = append(prefixes, filepath.Join(homeDir, ".linuxbrew"))
+		}
+	}
+	for _, prefix := range prefixes {
+		// The "opt" directory is only present in a Homebrew prefix, which
+		// avoids treating any "/usr/local" as a Homebrew installation.
+		if pathutil.IsDir(filepath.Join(prefix, "opt")) && pathutil.IsDir(filepath.Join(prefix, "Cellar")) {
+			return &homebrewProvider{prefix: prefix}
+		}
+	}
+	return nil
+}
+
+func (p *homebrewProvider) Name() string {
+	return "homebrew"
+}
+
+func (p *homebrewProvider) Executables() ([]string, error) {
+	// Each entry in the "opt" directory is a symlink to the installed
+	// version of the formula in the Cellar, e.g., "opt/python@3.11".
+	kegs, err := filepath.Glob(filepath.Join(p.prefix, "opt", "python@*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var executables []string
+
+	for _, keg := range kegs {
+		execs, err := execsInPath(filepath.Join(keg, "bin"))
+		if err != nil {
+			return nil, err
+		}
+		executables = append(executables, execs...)
+	}
+
+	return executables, nil
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHomebrewProvider(t *testing.T) {
+	prefix := t.TempDir()
+	t.Setenv("HOMEBREW_PREFIX", prefix)
+
+	var want []string
+	for _, formula := range []string{"python@3.10", "python@3.11"} {
+		keg := filepath.Join(prefix, "Cellar", formula, "1")
+		writeFakePython(t, filepath.Join(keg, "bin"))
+		want = append(want, filepath.Join(prefix, "opt", formula, "bin", pythonExeName))
+		if err := os.MkdirAll(filepath.Join(prefix, "opt"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(keg, filepath.Join(prefix, "opt", formula)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Unrelated formulas should not be considered.
+	writeFakePython(t, filepath.Join(prefix, "opt", "pyenv", "bin"))
+
+	p := newHomebrewProvider()
+	if p == nil {
+		t.Fatal("newHomebrewProvider() = nil, want non-nil")
+	}
+
+	got, err := p.Executables()
+	if err != nil {
+		t.Fatalf("Executables() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Executables() = %q, want %q", got, want)
+	}
+}
@@ -22,6 +22,10 @@ func newMacOSProvider() *macosProvider {
 	return &macosProvider{}
 }
 
+func (p *macosProvider) Name() string {
+	return "macos"
+}
+
 func (p *macosProvider) Executables() ([]string, error) {
 	entries, err := os.ReadDir(versionDir)
 	if err != nil {
@@ -0,0 +1,80 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// miseProvider is a Provider that finds Python executables in the mise
+// installation. It also supports rtx, which is the former name of mise.
+type miseProvider struct {
+	// roots are the data directories of the mise and rtx installations.
+	roots []string
+}
+
+// newMiseProvider returns a new miseProvider.
+//
+// It will return nil if neither mise nor rtx is installed. This is deduced
+// by checking the environment variables MISE_DATA_DIR and RTX_DATA_DIR,
+// fallback to the default data directory of the respective tool.
+func newMiseProvider() *miseProvider {
+	var roots []string
+	for _, tool := range []string{"mise", "rtx"} {
+		root, err := miseRoot(tool)
+		if err != nil {
+			continue
+		}
+		if pathutil.IsDir(root) {
+			roots = append(roots, root)
+		}
+	}
+	if len(roots) == 0 {
+		return nil
+	}
+	return &miseProvider{roots: roots}
+}
+
+// miseRoot returns the data directory of the given tool, which is either mise
+// or rtx, using the MISE_DATA_DIR or RTX_DATA_DIR environment variable if set,
+// or the default one.
+func miseRoot(tool string) (string, error) {
+	if root := os.Getenv(strings.ToUpper(tool) + "_DATA_DIR"); root != "" {
+		return root, nil
+	}
+	return miseDataDir(tool)
+}
+
+// miseDataDir returns the default data directory for the given tool, which
+// is either mise or rtx.
+func miseDataDir(tool string) (string, error) {
+	if runtime.GOOS == "windows" {
+		if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, tool), nil
+		}
+	}
+	return userDataDir(tool)
+}
+
+func (p *miseProvider) Name() string {
+	return "mise"
+}
+
+func (p *miseProvider) Executables() ([]string, error) {
+	var executables []string
+
+	for _, root := range p.roots {
+		// The aliases like "latest" or "3.12" are symlinks to the actual
+		// version directories, which are skipped by execsInSubdirs.
+		execs, err := execsInSubdirs(filepath.Join(root, "installs", "python"), binDir)
+		if err != nil {
+			return nil, err
+		}
+		executables = append(executables, execs...)
+	}
+
+	return executables, nil
+}
@@ -0,0 +1,78 @@
+package pythonfinder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pathProvider is a Provider that finds Python executables in the PATH
+// environment variable.
+//
+// The shims of the version managers like pyenv are resolved to the Python
+// executables they dispatch to in the current directory.
+type pathProvider struct {
+	paths []string
+
+	// shims maps the shims directories of the version managers to the
+	// version manager they belong to.
+	shims map[string]shimDir
+}
+
+// newPathProvider returns a new pathProvider.
+func newPathProvider() *pathProvider {
+	return &pathProvider{
+		paths: strings.Split(os.Getenv("PATH"), string(os.PathListSeparator)),
+		shims: shimDirs(),
+	}
+}
+
+func (p *pathProvider) Name() string {
+	return "path"
+}
+
+func (p *pathProvider) Executables() ([]string, error) {
+	return p.executablesContext(context.Background())
+}
+
+func (p *pathProvider) executablesContext(ctx context.Context) ([]string, error) {
+	var executables []string
+	// The shims directories whose version manager failed are skipped, so
+	// that the other directories are still considered.
+	var errs providerErrors
+	for _, path := range p.paths {
+		execs, err := execsInPath(path)
+		if err != nil {
+			return nil, err
+		}
+		dir, ok := p.shims[filepath.Clean(path)]
+		if !ok {
+			executables = append(executables, execs...)
+			continue
+		}
+		if len(execs) == 0 {
+			continue
+		}
+		prefixes, err := dir.prefixes(ctx)
+		if err != nil {
+			// The version manager is missing, failed or does not select any
+			// installed Python version in the current directory.
+			errs = append(errs, Diagnostic{
+				Path: dir.executable,
+				Err:  fmt.Errorf("cannot resolve the shims in %s: %w", path, err),
+			})
+			continue
+		}
+		for _, shim := range execs {
+			if executable, ok := resolveShim(prefixes, shim); ok {
+				executables = append(executables, executable)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return executables, errs
+	}
+	return executables, nil
+}
@@ -0,0 +1,98 @@
+package pythonfinder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPathProvider(t *testing.T) {
+	want := []string{
+		"/bin",
+		"/usr/bin",
+		"/usr/local/bin",
+	}
+	t.Setenv("PATH", strings.Join(want, string(os.PathListSeparator)))
+
+	p := newPathProvider()
+	if !reflect.DeepEqual(p.paths, want) {
+		t.Errorf("got %q, want %q", p.paths, want)
+	}
+}
+
+func TestPathProviderShims(t *testing.T) {
+	testCaseName = "TestPathProviderShims"
+	execCommandContext = fakeExecCommandContext
+	defer func() {
+		execCommandContext = exec.CommandContext
+	}()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the version managers do not use shims on Windows")
+	}
+
+	root := t.TempDir()
+	t.Setenv("PYENV_ROOT", root)
+	shims := filepath.Join(root, "shims")
+	python3 := filepath.Join(root, "versions", "3.11.7", "bin", "python3")
+	// The version manager is not in PATH, only its shims directory is.
+	for _, path := range []string{
+		filepath.Join(shims, "python2"),
+		filepath.Join(shims, "python3"),
+		filepath.Join(root, "bin", "pyenv"),
+		python3,
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	other := t.TempDir()
+	writeFakePython(t, other)
+	t.Setenv("PATH", strings.Join([]string{shims, other}, string(os.PathListSeparator)))
+
+	p := newPathProvider()
+	got, err := p.Executables()
+	if err != nil {
+		t.Fatalf("Executables() error = %v", err)
+	}
+	// The python2 shim does not dispatch to an installed version.
+	want := []string{python3, filepath.Join(other, pythonExeName)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Executables() = %q, want %q", got, want)
+	}
+
+	// The shims cannot be resolved once the search is cancelled, which is
+	// reported along with the other executables.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = p.executablesContext(ctx)
+	want = []string{filepath.Join(other, pythonExeName)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("executablesContext() = %q, want %q", got, want)
+	}
+	var errs providerErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != filepath.Join(root, "bin", "pyenv") {
+		t.Errorf("executablesContext() error = %v, want a failure of %q", err, filepath.Join(root, "bin", "pyenv"))
+	}
+
+	// The shims cannot be resolved without the version manager.
+	if err := os.Remove(filepath.Join(root, "bin", "pyenv")); err != nil {
+		t.Fatal(err)
+	}
+	got, err = newPathProvider().Executables()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Executables() = %q, want %q", got, want)
+	}
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "" {
+		t.Errorf("Executables() error = %v, want a missing version manager failure", err)
+	}
+}
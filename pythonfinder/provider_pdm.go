@@ -0,0 +1,51 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// pdmProvider is a Provider that finds Python executables installed by pdm
+// using `pdm python install`.
+type pdmProvider struct {
+	// root is the directory where pdm installs the Python interpreters.
+	root string
+}
+
+// newPdmProvider returns a new pdmProvider.
+//
+// It will return nil if there are no pdm managed Python installations. This
+// is deduced by checking the environment variable PDM_PYTHON_INSTALL_ROOT,
+// which mirrors the "python.install_root" configuration, fallback to the
+// default pdm data directory.
+func newPdmProvider() *pdmProvider {
+	root := os.Getenv("PDM_PYTHON_INSTALL_ROOT")
+	if root == "" {
+		dataDir, err := platformDataDir("pdm")
+		if err != nil {
+			return nil
+		}
+		// platformdirs uses the application name as the author on Windows.
+		if runtime.GOOS == "windows" {
+			dataDir = filepath.Join(dataDir, "pdm")
+		}
+		root = filepath.Join(dataDir, "python")
+	}
+	if !pathutil.IsDir(root) {
+		return nil
+	}
+	return &pdmProvider{root: root}
+}
+
+func (p *pdmProvider) Name() string {
+	return "pdm"
+}
+
+func (p *pdmProvider) Executables() ([]string, error) {
+	// Each interpreter is installed in a directory named after it, for
+	// example, "cpython@3.12.1/bin/python3".
+	return execsInSubdirs(p.root, binDir)
+}
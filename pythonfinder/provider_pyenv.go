@@ -21,20 +21,30 @@ type pyenvProvider struct {
 // the environment variable PYENV_ROOT, fallback to the default pyenv
 // installation directory.
 func newPyenvProvider() *pyenvProvider {
-	root := os.Getenv("PYENV_ROOT")
-	if root == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil
-		}
-		root = filepath.Join(homeDir, ".pyenv")
-	}
-	if !pathutil.IsDir(root) {
+	root, err := pyenvRoot()
+	if err != nil || !pathutil.IsDir(root) {
 		return nil
 	}
 	return &pyenvProvider{root: root}
 }
 
+// pyenvRoot returns the root directory of the pyenv installation, which is
+// the PYENV_ROOT environment variable if set, or the default one.
+func pyenvRoot() (string, error) {
+	if root := os.Getenv("PYENV_ROOT"); root != "" {
+		return root, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".pyenv"), nil
+}
+
+func (p *pyenvProvider) Name() string {
+	return "pyenv"
+}
+
 func (p *pyenvProvider) Executables() ([]string, error) {
 	versionDir := filepath.Join(p.root, "versions")
 	if !pathutil.IsDir(versionDir) {
@@ -0,0 +1,284 @@
+package pythonfinder
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// registryRoot is a predefined key of the Windows registry.
+type registryRoot int
+
+const (
+	hkeyCurrentUser registryRoot = iota
+	hkeyLocalMachine
+)
+
+func (r registryRoot) String() string {
+	if r == hkeyCurrentUser {
+		return "HKEY_CURRENT_USER"
+	}
+	return "HKEY_LOCAL_MACHINE"
+}
+
+// registryKey is a key of the Windows registry.
+type registryKey struct {
+	root registryRoot
+
+	// path is the path to the key relative to the root, separated by "\".
+	path string
+}
+
+// subKey returns the subkey of the key with the given name.
+func (k registryKey) subKey(name string) registryKey {
+	return registryKey{root: k.root, path: k.path + `\` + name}
+}
+
+func (k registryKey) String() string {
+	return k.root.String() + `\` + k.path
+}
+
+// registryReader reads the keys and values of the Windows registry. This is
+// an interface so that the registry provider can be tested on any system.
+type registryReader interface {
+	// subKeyNames returns the names of the subkeys of the given key. The
+	// error wraps fs.ErrNotExist if the key does not exist.
+	subKeyNames(key registryKey) ([]string, error)
+
+	// stringValue returns the string value with the given name of the given
+	// key, where the empty name refers to the default value. The error wraps
+	// fs.ErrNotExist if either the key or the value does not exist.
+	stringValue(key registryKey, name string) (string, error)
+}
+
+// registryHive is a registry key containing the Python installations as per
+// PEP 514, organized by company and tag, e.g., "PythonCore\3.12".
+type registryHive struct {
+	key registryKey
+
+	// arch is the architecture of the installations which do not specify
+	// the "SysArchitecture" value. This is empty if it's unknown.
+	arch string
+}
+
+// registryHives are the keys searched for the Python installations, in the
+// order defined by PEP 514. The 32-bit installations on a 64-bit system are
+// registered in the "Wow6432Node" key of the local machine.
+var registryHives = []registryHive{
+	{key: registryKey{root: hkeyCurrentUser, path: `Software\Python`}},
+	{key: registryKey{root: hkeyLocalMachine, path: `Software\Python`}},
+	{key: registryKey{root: hkeyLocalMachine, path: `Software\Wow6432Node\Python`}, arch: "32bit"},
+}
+
+// registryEntry is a Python installation registered as per PEP 514.
+type registryEntry struct {
+	// company is the name of the distributor, e.g., "PythonCore" for the
+	// python.org installers.
+	company string
+
+	// tag identifies the installation of the company, e.g., "3.12" or
+	// "3.12-32" for a 32-bit installation.
+	tag string
+
+	// installPath is the directory the Python is installed in.
+	installPath string
+
+	// executable is the path to the Python executable.
+	executable string
+
+	// arch is the architecture of the installation, "32bit" or "64bit". This
+	// is empty if it's unknown.
+	arch string
+}
+
+// RegistryInfo is the registration of a Python installation in the Windows
+// registry as per PEP 514.
+type RegistryInfo struct {
+	// Company is the name of the distributor, e.g., "PythonCore" for the
+	// python.org installers.
+	Company string
+
+	// Tag identifies the installation of the company, e.g., "3.12".
+	Tag string
+
+	// InstallPath is the directory the Python is installed in.
+	InstallPath string
+}
+
+// registryProvider is a Provider that finds the Python executables registered
+// in the Windows registry as per PEP 514, e.g., by the python.org installers.
+type registryProvider struct {
+	reader registryReader
+
+	// registered maps the paths to the Python executables returned by the
+	// last call to Executables to their entry in the registry.
+	registered map[string]registryEntry
+}
+
+// newRegistryProvider returns a new registryProvider using the given reader.
+func newRegistryProvider(reader registryReader) *registryProvider {
+	return &registryProvider{reader: reader}
+}
+
+func (p *registryProvider) Name() string {
+	return "registry"
+}
+
+func (p *registryProvider) Executables() ([]string, error) {
+	entries, err := p.entries()
+
+	p.registered = make(map[string]registryEntry)
+	executables := make([]string, len(entries))
+	for i, entry := range entries {
+		executables[i] = entry.executable
+		p.registered[registryPathKey(entry.executable)] = entry
+		// The finder annotates the Python executables using their resolved
+		// path.
+		if resolved, err := evalSymlinks(entry.executable); err == nil {
+			p.registered[registryPathKey(resolved)] = entry
+		}
+	}
+	return executables, err
+}
+
+// annotate records the registration of the given Python executable, and
+// fills in its pointer size and architecture from the "SysArchitecture"
+// value if they could not be introspected.
+func (p *registryProvider) annotate(pythonExecutable *PythonExecutable) {
+	entry, ok := p.registered[registryPathKey(pythonExecutable.Path)]
+	if !ok {
+		return
+	}
+	pythonExecutable.Registry = &RegistryInfo{
+		Company:     entry.company,
+		Tag:         entry.tag,
+		InstallPath: entry.installPath,
+	}
+	if pythonExecutable.PointerSize == 0 {
+		switch entry.arch {
+		case "32bit":
+			pythonExecutable.PointerSize = 32
+		case "64bit":
+			pythonExecutable.PointerSize = 64
+		}
+	}
+	// The 32-bit Python installers for Windows are only built for x86, but
+	// the 64-bit ones are built for both x86_64 and ARM64.
+	if pythonExecutable.Arch == "" && entry.arch == "32bit" {
+		pythonExecutable.Arch = "x86"
+	}
+}
+
+// registryPathKey returns the key of the given path in the registered map,
+// as the paths are case-insensitive on Windows.
+func registryPathKey(path string) string {
+	return strings.ToLower(filepath.Clean(path))
+}
+
+// entries returns all the Python installations in the registry, in the order
+// of the hives, then sorted by company and tag. The entries read before an
+// error are returned along with it.
+func (p *registryProvider) entries() ([]registryEntry, error) {
+	var entries []registryEntry
+
+	for _, hive := range registryHives {
+		companies, err := p.subKeyNames(hive.key)
+		if err != nil {
+			return entries, err
+		}
+		for _, company := range companies {
+			// The key is reserved for the Python launcher, which is not a
+			// Python installation.
+			if company == "PyLauncher" {
+				continue
+			}
+			companyKey := hive.key.subKey(company)
+			tags, err := p.subKeyNames(companyKey)
+			if err != nil {
+				return entries, err
+			}
+			for _, tag := range tags {
+				entry, ok, err := p.entry(hive, company, tag)
+				if err != nil {
+					return entries, err
+				}
+				if ok {
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// entry returns the Python installation registered in the given hive for the
+// given company and tag. It returns false if the installation does not have
+// an install path, in which case it must be ignored as per PEP 514.
+func (p *registryProvider) entry(hive registryHive, company, tag string) (registryEntry, bool, error) {
+	tagKey := hive.key.subKey(company).subKey(tag)
+	installPathKey := tagKey.subKey("InstallPath")
+
+	installPath, err := p.stringValue(installPathKey, "")
+	if err != nil || installPath == "" {
+		return registryEntry{}, false, err
+	}
+
+	executable, err := p.stringValue(installPathKey, "ExecutablePath")
+	if err != nil {
+		return registryEntry{}, false, err
+	}
+	if executable == "" {
+		// Only the python.org installers are allowed to omit the
+		// executable path, in which case it's in the install path.
+		executable = filepath.Join(installPath, "python.exe")
+	}
+
+	arch, err := p.stringValue(tagKey, "SysArchitecture")
+	if err != nil {
+		return registryEntry{}, false, err
+	}
+	if arch == "" {
+		arch = hive.arch
+		if company == "PythonCore" && strings.HasSuffix(tag, "-32") {
+			arch = "32bit"
+		}
+	}
+
+	return registryEntry{
+		company:     company,
+		tag:         tag,
+		installPath: installPath,
+		executable:  executable,
+		arch:        arch,
+	}, true, nil
+}
+
+// subKeyNames returns the sorted names of the subkeys of the given key, or
+// nil if the key does not exist.
+func (p *registryProvider) subKeyNames(key registryKey) ([]string, error) {
+	names, err := p.reader.subKeyNames(key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// stringValue returns the string value with the given name of the given key,
+// or an empty string if it does not exist.
+func (p *registryProvider) stringValue(key registryKey, name string) (string, error) {
+	value, err := p.reader.stringValue(key, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,180 @@
+package pythonfinder
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRegistry is an in-memory registryReader which maps the full path of
+// each key, e.g., `HKEY_CURRENT_USER\Software\Python`, to its values.
+type fakeRegistry map[string]map[string]string
+
+func (r fakeRegistry) subKeyNames(key registryKey) ([]string, error) {
+	prefix := key.String() + `\`
+	seen := make(map[string]bool)
+	var names []string
+	for path := range r {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		name, _, _ := strings.Cut(path[len(prefix):], `\`)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if _, ok := r[key.String()]; !ok && len(names) == 0 {
+		return nil, fmt.Errorf("%s: %w", key, fs.ErrNotExist)
+	}
+	return names, nil
+}
+
+func (r fakeRegistry) stringValue(key registryKey, name string) (string, error) {
+	values, ok := r[key.String()]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", key, fs.ErrNotExist)
+	}
+	value, ok := values[name]
+	if !ok {
+		return "", fmt.Errorf("%s: %s: %w", key, name, fs.ErrNotExist)
+	}
+	return value, nil
+}
+
+func TestRegistryProvider(t *testing.T) {
+	reader := fakeRegistry{
+		`HKEY_CURRENT_USER\Software\Python\PythonCore\3.12\InstallPath`: {
+			"": `C:\Users\user\AppData\Local\Programs\Python\Python312`,
+		},
+		`HKEY_CURRENT_USER\Software\Python\PythonCore\3.11-32\InstallPath`: {
+			"": `C:\Users\user\AppData\Local\Programs\Python\Python311-32`,
+		},
+		`HKEY_CURRENT_USER\Software\Python\PyLauncher`: {
+			"": `C:\Windows\py.exe`,
+		},
+		`HKEY_LOCAL_MACHINE\Software\Python\ContinuumAnalytics\Anaconda39-64`: {
+			"SysArchitecture": "64bit",
+		},
+		`HKEY_LOCAL_MACHINE\Software\Python\ContinuumAnalytics\Anaconda39-64\InstallPath`: {
+			"":               `C:\ProgramData\Anaconda3`,
+			"ExecutablePath": `C:\ProgramData\Anaconda3\python.exe`,
+		},
+		// The installation without an install path must be ignored.
+		`HKEY_LOCAL_MACHINE\Software\Python\Example\Broken`: {
+			"SysArchitecture": "64bit",
+		},
+		`HKEY_LOCAL_MACHINE\Software\Wow6432Node\Python\PythonCore\3.10\InstallPath`: {
+			"": `C:\Program Files (x86)\Python310-32`,
+		},
+	}
+
+	p := newRegistryProvider(reader)
+	got, err := p.entries()
+	if err != nil {
+		t.Fatalf("entries() error = %v", err)
+	}
+	want := []registryEntry{
+		{
+			company:     "PythonCore",
+			tag:         "3.11-32",
+			installPath: `C:\Users\user\AppData\Local\Programs\Python\Python311-32`,
+			executable:  filepath.Join(`C:\Users\user\AppData\Local\Programs\Python\Python311-32`, "python.exe"),
+			arch:        "32bit",
+		},
+		{
+			company:     "PythonCore",
+			tag:         "3.12",
+			installPath: `C:\Users\user\AppData\Local\Programs\Python\Python312`,
+			executable:  filepath.Join(`C:\Users\user\AppData\Local\Programs\Python\Python312`, "python.exe"),
+		},
+		{
+			company:     "ContinuumAnalytics",
+			tag:         "Anaconda39-64",
+			installPath: `C:\ProgramData\Anaconda3`,
+			executable:  `C:\ProgramData\Anaconda3\python.exe`,
+			arch:        "64bit",
+		},
+		{
+			company:     "PythonCore",
+			tag:         "3.10",
+			installPath: `C:\Program Files (x86)\Python310-32`,
+			executable:  filepath.Join(`C:\Program Files (x86)\Python310-32`, "python.exe"),
+			arch:        "32bit",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("entries() = %+v, want %+v", got, want)
+	}
+
+	executables, err := p.Executables()
+	if err != nil {
+		t.Fatalf("Executables() error = %v", err)
+	}
+	for i, entry := range want {
+		if i >= len(executables) || executables[i] != entry.executable {
+			t.Errorf("Executables() = %q, want the executables of %+v", executables, want)
+			break
+		}
+	}
+
+	// The pointer size is filled in from the registry if it could not be
+	// introspected, but the introspected architecture is kept.
+	pythonExecutable := &PythonExecutable{Path: want[0].executable}
+	p.annotate(pythonExecutable)
+	wantRegistry := &RegistryInfo{
+		Company:     "PythonCore",
+		Tag:         "3.11-32",
+		InstallPath: `C:\Users\user\AppData\Local\Programs\Python\Python311-32`,
+	}
+	if !reflect.DeepEqual(pythonExecutable.Registry, wantRegistry) ||
+		pythonExecutable.PointerSize != 32 || pythonExecutable.Arch != "x86" {
+		t.Errorf("annotate(%q) = (%+v, %d, %q), want (%+v, %d, %q)",
+			pythonExecutable.Path, pythonExecutable.Registry, pythonExecutable.PointerSize, pythonExecutable.Arch,
+			wantRegistry, 32, "x86")
+	}
+
+	pythonExecutable = &PythonExecutable{Path: want[2].executable, Arch: "AMD64", PointerSize: 64}
+	p.annotate(pythonExecutable)
+	if pythonExecutable.Registry == nil || pythonExecutable.Registry.Company != "ContinuumAnalytics" ||
+		pythonExecutable.PointerSize != 64 || pythonExecutable.Arch != "AMD64" {
+		t.Errorf("annotate(%q) = (%+v, %d, %q), want the ContinuumAnalytics registration",
+			pythonExecutable.Path, pythonExecutable.Registry, pythonExecutable.PointerSize, pythonExecutable.Arch)
+	}
+
+	// The Python executables which are not registered are not annotated.
+	pythonExecutable = &PythonExecutable{Path: `C:\Python27\python.exe`}
+	p.annotate(pythonExecutable)
+	if pythonExecutable.Registry != nil {
+		t.Errorf("annotate(%q).Registry = %+v, want nil", pythonExecutable.Path, pythonExecutable.Registry)
+	}
+}
+
+// failingRegistry is a registryReader which fails to read any key.
+type failingRegistry struct{}
+
+func (failingRegistry) subKeyNames(registryKey) ([]string, error) {
+	return nil, errors.New("access denied")
+}
+
+func (failingRegistry) stringValue(registryKey, string) (string, error) {
+	return "", errors.New("access denied")
+}
+
+func TestRegistryProviderError(t *testing.T) {
+	p := newRegistryProvider(failingRegistry{})
+	if _, err := p.Executables(); err == nil {
+		t.Errorf("Executables() error = nil, want non-nil")
+	}
+
+	// A missing registry key is not an error.
+	p = newRegistryProvider(fakeRegistry{})
+	executables, err := p.Executables()
+	if err != nil || len(executables) != 0 {
+		t.Errorf("Executables() = (%q, %v), want (nil, nil)", executables, err)
+	}
+}
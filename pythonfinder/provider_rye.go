@@ -0,0 +1,48 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// ryeProvider is a Provider that finds Python executables fetched by rye
+// using `rye fetch`.
+type ryeProvider struct {
+	// root is the root directory of the rye installation.
+	root string
+}
+
+// newRyeProvider returns a new ryeProvider.
+//
+// It will return nil if rye is not installed. This is deduced by checking
+// the environment variable RYE_HOME, fallback to the default rye
+// installation directory.
+func newRyeProvider() *ryeProvider {
+	root := os.Getenv("RYE_HOME")
+	if root == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		root = filepath.Join(homeDir, ".rye")
+	}
+	if !pathutil.IsDir(root) {
+		return nil
+	}
+	return &ryeProvider{root: root}
+}
+
+func (p *ryeProvider) Name() string {
+	return "rye"
+}
+
+func (p *ryeProvider) Executables() ([]string, error) {
+	// Older versions of rye kept the toolchain in an "install" subdirectory.
+	return execsInSubdirs(
+		filepath.Join(p.root, "py"),
+		binDir,
+		filepath.Join("install", binDir),
+	)
+}
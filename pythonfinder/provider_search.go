@@ -0,0 +1,70 @@
+package pythonfinder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// searchPathsProvider is a Provider that finds Python executables in the
+// directories configured by the user, e.g., the ones containing the vendor
+// builds which are not installed by any known tool.
+type searchPathsProvider struct {
+	// patterns are the directories to search, which can be glob patterns.
+	patterns []string
+}
+
+// newSearchPathsProvider returns a new searchPathsProvider for the given
+// directories, or glob patterns.
+//
+// It will return nil if no directories are given.
+func newSearchPathsProvider(patterns []string) *searchPathsProvider {
+	if len(patterns) == 0 {
+		return nil
+	}
+	return &searchPathsProvider{patterns: patterns}
+}
+
+func (p *searchPathsProvider) Name() string {
+	return "search-paths"
+}
+
+func (p *searchPathsProvider) Executables() ([]string, error) {
+	var executables []string
+	for _, pattern := range p.patterns {
+		pattern, err := expandHome(pattern)
+		if err != nil {
+			return nil, err
+		}
+		dirs, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search path %q: %w", pattern, err)
+		}
+		for _, dir := range dirs {
+			dir, err := filepath.Abs(dir)
+			if err != nil {
+				return nil, err
+			}
+			execs, err := execsInPath(dir)
+			if err != nil {
+				return nil, err
+			}
+			executables = append(executables, execs...)
+		}
+	}
+	return executables, nil
+}
+
+// expandHome replaces the "~" prefix of the given path with the home
+// directory of the current user.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
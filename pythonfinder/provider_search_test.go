@@ -0,0 +1,47 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSearchPathsProvider(t *testing.T) {
+	// The executables are found through a symbolic link to the directory,
+	// and their paths should not be resolved by the provider.
+	root := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(t.TempDir(), root); err != nil {
+		t.Skipf("Symlink() error = %v", err)
+	}
+
+	want := []string{
+		writeFakePython(t, filepath.Join(root, "python-3.10", "bin")),
+		writeFakePython(t, filepath.Join(root, "python-3.11", "bin")),
+		writeFakePython(t, filepath.Join(root, "extra")),
+	}
+	// Only the directories matching the patterns should be considered.
+	writeFakePython(t, filepath.Join(root, "other", "bin"))
+
+	if p := newSearchPathsProvider(nil); p != nil {
+		t.Errorf("newSearchPathsProvider(nil) = %v, want nil", p)
+	}
+
+	p := newSearchPathsProvider([]string{
+		filepath.Join(root, "python-*", "bin"),
+		filepath.Join(root, "extra"),
+		filepath.Join(root, "missing"),
+	})
+	got, err := p.Executables()
+	if err != nil {
+		t.Fatalf("Executables() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Executables() = %q, want %q", got, want)
+	}
+
+	p = newSearchPathsProvider([]string{"["})
+	if _, err := p.Executables(); err == nil {
+		t.Errorf("Executables() error = nil, want an invalid pattern error")
+	}
+}
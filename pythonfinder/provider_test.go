@@ -0,0 +1,371 @@
+package pythonfinder
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pythonExeName is the name of the fake Python executable created by
+// writeFakePython, as it would be named on the current platform.
+var pythonExeName = "python"
+
+func init() {
+	if runtime.GOOS == "windows" {
+		pythonExeName = "python.exe"
+	}
+}
+
+// writeFakePython creates an empty executable file named pythonExeName in
+// the given directory, creating the directory if required, and returns the
+// path to it. The path is not resolved, like the ones returned by the
+// providers.
+func writeFakePython(t *testing.T, dir string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("MkdirAll(%q) error = %v", dir, err)
+	}
+	name := filepath.Join(dir, pythonExeName)
+	if err := os.WriteFile(name, nil, 0o755); err != nil {
+		t.Fatalf("WriteFile(%q) error = %v", name, err)
+	}
+	return name
+}
+
+// resolvePath returns the path with all the symbolic links resolved, for the
+// paths which are resolved by the finder, like the candidates.
+func resolvePath(t *testing.T, name string) string {
+	t.Helper()
+	resolved, err := filepath.EvalSymlinks(name)
+	if err != nil {
+		t.Fatalf("EvalSymlinks(%q) error = %v", name, err)
+	}
+	return resolved
+}
+
+func TestProviders(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// setup creates the Python installations of the tool inside the given
+		// directory, and returns the provider and the executables it should
+		// find.
+		setup func(t *testing.T, root string) (Provider, []string)
+
+		// check optionally checks the provider further, given the
+		// executables it found.
+		check func(t *testing.T, p Provider, got []string)
+	}{
+		{
+			name: "conda",
+			setup: func(t *testing.T, home string) (Provider, []string) {
+				t.Setenv("HOME", home)
+				t.Setenv("USERPROFILE", home)
+
+				root := filepath.Join(home, "miniforge3")
+				if err := os.MkdirAll(filepath.Join(root, "conda-meta"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				// An environment registered with conda but living outside of
+				// any root.
+				external := filepath.Join(home, "projects", "env")
+				want := []string{
+					writeFakePython(t, filepath.Join(root, binDir)),
+					writeFakePython(t, filepath.Join(root, "envs", "data", binDir)),
+					writeFakePython(t, filepath.Join(external, binDir)),
+				}
+
+				registered := strings.Join([]string{
+					filepath.Join(root, "envs", "data"),
+					external,
+					filepath.Join(home, "does-not-exist"),
+				}, "\n")
+				if err := os.MkdirAll(filepath.Join(home, ".conda"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(home, ".conda", "environments.txt"), []byte(registered), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				t.Setenv("CONDA_PREFIX", filepath.Join(root, "envs", "data"))
+				t.Setenv("CONDA_EXE", filepath.Join(root, "bin", "conda"))
+				return newCondaProvider(), want
+			},
+		},
+		{
+			name: "hatch",
+			setup: func(t *testing.T, root string) (Provider, []string) {
+				t.Setenv("HATCH_DATA_DIR", root)
+				want := []string{
+					writeFakePython(t, filepath.Join(root, "pythons", "3.12", "python", binDir)),
+					writeFakePython(t, filepath.Join(root, "pythons", "pypy3.10", "python", binDir)),
+				}
+				return newHatchProvider(), want
+			},
+		},
+		{
+			name: "mise",
+			setup: func(t *testing.T, root string) (Provider, []string) {
+				miseRoot, rtxRoot := filepath.Join(root, "mise"), filepath.Join(root, "rtx")
+				t.Setenv("MISE_DATA_DIR", miseRoot)
+				t.Setenv("RTX_DATA_DIR", rtxRoot)
+
+				installs := filepath.Join(miseRoot, "installs", "python")
+				want := []string{
+					writeFakePython(t, filepath.Join(installs, "3.11.7", binDir)),
+					writeFakePython(t, filepath.Join(installs, "3.12.1", binDir)),
+					writeFakePython(t, filepath.Join(rtxRoot, "installs", "python", "3.10.13", binDir)),
+				}
+				if err := os.Symlink(filepath.Join(installs, "3.12.1"), filepath.Join(installs, "latest")); err != nil {
+					t.Logf("Symlink() error = %v", err)
+				}
+				return newMiseProvider(), want
+			},
+		},
+		{
+			name: "pdm",
+			setup: func(t *testing.T, root string) (Provider, []string) {
+				t.Setenv("PDM_PYTHON_INSTALL_ROOT", root)
+				want := []string{
+					writeFakePython(t, filepath.Join(root, "cpython@3.11.7", binDir)),
+					writeFakePython(t, filepath.Join(root, "cpython@3.12.1", binDir)),
+				}
+				return newPdmProvider(), want
+			},
+		},
+		{
+			name: "rye",
+			setup: func(t *testing.T, root string) (Provider, []string) {
+				t.Setenv("RYE_HOME", root)
+				want := []string{
+					writeFakePython(t, filepath.Join(root, "py", "cpython@3.11.1", "install", binDir)),
+					writeFakePython(t, filepath.Join(root, "py", "cpython@3.12.1", binDir)),
+				}
+				return newRyeProvider(), want
+			},
+		},
+		{
+			name: "uv",
+			setup: func(t *testing.T, root string) (Provider, []string) {
+				t.Setenv("UV_PYTHON_INSTALL_DIR", root)
+				// The provider resolves the installation directory to match
+				// the paths resolved by the finder.
+				want := []string{
+					resolvePath(t, writeFakePython(t, filepath.Join(root, "cpython-3.12.1-linux-x86_64-gnu", binDir))),
+					resolvePath(t, writeFakePython(t, filepath.Join(root, "pypy-3.10.14-linux-aarch64-gnu", binDir))),
+				}
+				// uv keeps its cache and temporary files in hidden
+				// directories.
+				writeFakePython(t, filepath.Join(root, ".cache", "cpython-3.11.0-linux-x86_64-gnu", binDir))
+				if err := os.WriteFile(filepath.Join(root, ".lock"), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return newUvProvider(), want
+			},
+			check: func(t *testing.T, p Provider, got []string) {
+				pythonExecutable := &PythonExecutable{Path: got[1]}
+				p.(annotator).annotate(pythonExecutable)
+				if pythonExecutable.Implementation != "pypy" || pythonExecutable.Arch != "aarch64" {
+					t.Errorf("annotate(%q) = (%q, %q), want (%q, %q)",
+						got[1], pythonExecutable.Implementation, pythonExecutable.Arch, "pypy", "aarch64")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, want := tt.setup(t, t.TempDir())
+			// The constructors return a typed nil pointer if the tool is not
+			// installed.
+			if p == nil || reflect.ValueOf(p).IsNil() {
+				t.Fatalf("new %s provider = nil, want non-nil", tt.name)
+			}
+
+			got, err := p.Executables()
+			if err != nil {
+				t.Fatalf("Executables() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Executables() = %q, want %q", got, want)
+			}
+			if tt.check != nil {
+				tt.check(t, p, got)
+			}
+		})
+	}
+}
+
+func TestLooksLikePython(t *testing.T) {
+	var tests map[string]bool
+
+	switch runtime.GOOS {
+	case "windows":
+		tests = map[string]bool{
+			"python.exe":         true,
+			"python3.exe":        true,
+			"python39.exe":       true,
+			"python310.exe":      true,
+			"pypy3.10.exe":       true,
+			"graalpy.exe":        true,
+			"python3.13t.exe":    true,
+			"python313t.exe":     true,
+			"python-build":       false,
+			"python-python3.exe": false,
+		}
+	default:
+		tests = map[string]bool{
+			"python":         true,
+			"python3":        true,
+			"python3.9":      true,
+			"python3.10":     true,
+			"pypy":           true,
+			"pypy3.10":       true,
+			"graalpy":        true,
+			"graalpy3.11":    true,
+			"python3.13t":    true,
+			"python3t":       true,
+			"pythont":        false,
+			"pypy-c":         false,
+			"python-build":   false,
+			"python-python3": false,
+		}
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := looksLikePython(name)
+			if got != want {
+				t.Errorf("looksLikePython(%q) = %v, want %v", name, got, want)
+			}
+		})
+	}
+}
+
+type fakeFileInfo struct {
+	dir      bool
+	basename string
+	modtime  time.Time
+	mode     fs.FileMode
+}
+
+func (f *fakeFileInfo) Name() string       { return f.basename }
+func (f *fakeFileInfo) Size() int64        { return 0 }
+func (f *fakeFileInfo) Mode() fs.FileMode  { return f.mode }
+func (f *fakeFileInfo) ModTime() time.Time { return f.modtime }
+func (f *fakeFileInfo) IsDir() bool        { return f.dir }
+func (f *fakeFileInfo) Sys() interface{}   { return nil }
+
+func TestIsExecutable(t *testing.T) {
+	var tests map[*fakeFileInfo]bool
+
+	switch runtime.GOOS {
+	case "windows":
+		tests = map[*fakeFileInfo]bool{
+			{basename: "python.exe"}: true,
+			{basename: "python"}:     false,
+		}
+	default:
+		tests = map[*fakeFileInfo]bool{
+			{mode: fs.ModeDir}: false,
+			{mode: 0o100}:      true,
+			{mode: 0o110}:      true,
+			{mode: 0o111}:      true,
+		}
+	}
+
+	for info, want := range tests {
+		t.Run(info.Mode().String(), func(t *testing.T) {
+			got := isExecutable(info)
+			if got != want {
+				t.Errorf("isExecutable(%v) = %v, want %v", info, got, want)
+			}
+		})
+	}
+}
+
+func TestParseProviders(t *testing.T) {
+	tests := []struct {
+		list []string
+		want []string
+	}{
+		{
+			list: nil,
+			want: ProviderNames(),
+		},
+		{
+			list: []string{"pyenv", "path"},
+			want: []string{"pyenv", "path"},
+		},
+		{
+			list: []string{" UV ", "pyenv", "uv"},
+			want: []string{"uv", "pyenv"},
+		},
+		{
+			list: []string{"-path", "-search-paths", "-macos", "-registry", "-pyenv", "-asdf", "-homebrew", "-mise"},
+			want: []string{"conda", "uv", "rye", "hatch", "pdm"},
+		},
+		{
+			list: []string{"conda", "path", "-path"},
+			want: []string{"conda"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.list, ","), func(t *testing.T) {
+			got, err := ParseProviders(tt.list)
+			if err != nil {
+				t.Fatalf("ParseProviders(%q) error = %v", tt.list, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseProviders(%q) = %q, want %q", tt.list, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseProviders([]string{"path", "-unknown"}); err == nil {
+		t.Errorf("ParseProviders() with an unknown provider error = nil, want non-nil")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	factories := providerFactories
+	t.Cleanup(func() {
+		providerFactories = factories
+	})
+
+	Register("custom", func() Provider {
+		return namedProvider{fakeProvider{"/custom/python"}, "custom"}
+	})
+	Register("unavailable", func() Provider {
+		return nil
+	})
+
+	names := ProviderNames()
+	if got := names[len(names)-2:]; !reflect.DeepEqual(got, []string{"custom", "unavailable"}) {
+		t.Errorf("ProviderNames() ends with %q, want the registered providers", got)
+	}
+	if _, err := ParseProviders([]string{"custom", "path"}); err != nil {
+		t.Errorf("ParseProviders() error = %v", err)
+	}
+
+	f := New(WithProviders([]string{"unavailable", "custom"}))
+	if len(f.providers) != 1 || f.providers[0].Name() != "custom" {
+		t.Errorf("New().providers = %v, want only the custom provider", f.providers)
+	}
+
+	for _, name := range []string{"custom", "Custom", "-custom", "a,b", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", name)
+				}
+			}()
+			Register(name, func() Provider { return nil })
+		}()
+	}
+}
@@ -0,0 +1,136 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// uvProvider is a Provider that finds Python executables installed by uv
+// using `uv python install`. These are python-build-standalone builds where
+// each build is installed in a directory named after the build, for example,
+// "cpython-3.12.1-linux-x86_64-gnu".
+type uvProvider struct {
+	// root is the directory containing all the uv managed Python builds.
+	root string
+}
+
+// newUvProvider returns a new uvProvider.
+//
+// It will return nil if there are no uv managed Python installations. This
+// is deduced by checking the environment variable UV_PYTHON_INSTALL_DIR,
+// fallback to the default uv data directory.
+func newUvProvider() *uvProvider {
+	root := os.Getenv("UV_PYTHON_INSTALL_DIR")
+	if root == "" {
+		dataDir, err := uvDataDir()
+		if err != nil {
+			return nil
+		}
+		root = filepath.Join(dataDir, "python")
+	}
+	if !pathutil.IsDir(root) {
+		return nil
+	}
+	// The executables are resolved by the finder, so the root should be
+	// resolved as well for annotate to find the build directory.
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil
+	}
+	return &uvProvider{root: root}
+}
+
+// uvDataDir returns the directory where uv stores its persistent data.
+func uvDataDir() (string, error) {
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "uv", "data"), nil
+		}
+	}
+	return userDataDir("uv")
+}
+
+func (p *uvProvider) Name() string {
+	return "uv"
+}
+
+func (p *uvProvider) Executables() ([]string, error) {
+	entries, err := os.ReadDir(p.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var executables []string
+
+	for _, entry := range entries {
+		// Skip the lock file, and the cache and temporary directories.
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		execs, err := execsInPath(filepath.Join(p.root, entry.Name(), binDir))
+		if err != nil {
+			return nil, err
+		}
+		executables = append(executables, execs...)
+	}
+
+	return executables, nil
+}
+
+// annotate fills in the implementation and architecture of the given Python
+// executable from the name of the build directory it belongs to, if they
+// could not be introspected.
+func (p *uvProvider) annotate(pythonExecutable *PythonExecutable) {
+	rel, err := filepath.Rel(p.root, pythonExecutable.Path)
+	if err != nil {
+		return
+	}
+	buildName, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+	build, ok := parseUvBuildName(buildName)
+	if !ok {
+		return
+	}
+	if pythonExecutable.Implementation == "" {
+		pythonExecutable.Implementation = build.implementation
+	}
+	if pythonExecutable.Arch == "" {
+		pythonExecutable.Arch = build.arch
+	}
+}
+
+// uvBuild contains the information encoded in the name of a uv managed
+// Python build directory.
+type uvBuild struct {
+	implementation string
+	version        string
+	os             string
+	arch           string
+	libc           string
+}
+
+// parseUvBuildName parses the name of a uv managed Python build directory
+// which is of the form "<implementation>-<version>-<os>-<arch>-<libc>".
+//
+// It returns false if the name is not of the expected form.
+func parseUvBuildName(name string) (uvBuild, bool) {
+	parts := strings.Split(name, "-")
+	if len(parts) != 5 {
+		return uvBuild{}, false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return uvBuild{}, false
+		}
+	}
+	return uvBuild{
+		implementation: parts[0],
+		version:        parts[1],
+		os:             parts[2],
+		arch:           parts[3],
+		libc:           parts[4],
+	}, true
+}
@@ -0,0 +1,42 @@
+package pythonfinder
+
+import "testing"
+
+func TestParseUvBuildName(t *testing.T) {
+	tests := []struct {
+		name string
+		want uvBuild
+		ok   bool
+	}{
+		{
+			name: "cpython-3.12.1-linux-x86_64-gnu",
+			want: uvBuild{"cpython", "3.12.1", "linux", "x86_64", "gnu"},
+			ok:   true,
+		},
+		{
+			name: "cpython-3.13.0+freethreaded-macos-aarch64-none",
+			want: uvBuild{"cpython", "3.13.0+freethreaded", "macos", "aarch64", "none"},
+			ok:   true,
+		},
+		{
+			name: "pypy-3.10.14-windows-x86_64-none",
+			want: uvBuild{"pypy", "3.10.14", "windows", "x86_64", "none"},
+			ok:   true,
+		},
+		{
+			name: "cpython-3.12.1",
+		},
+		{
+			name: "cpython--linux-x86_64-gnu",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseUvBuildName(tt.name)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("parseUvBuildName(%q) = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package pythonfinder
+
+import "regexp"
+
+// binDir is the directory, relative to the installation prefix, which
+// contains the Python executables.
+const binDir = "bin"
+
+var pythonFileRegex = regexp.MustCompile(`^(python|pypy|graalpy)(\d(\.\d\d?)?t?)?$`)
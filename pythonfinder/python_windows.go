@@ -0,0 +1,9 @@
+package pythonfinder
+
+import "regexp"
+
+// binDir is the directory, relative to the installation prefix, which
+// contains the Python executables. On Windows, it's the prefix itself.
+const binDir = ""
+
+var pythonFileRegex = regexp.MustCompile(`^(python|pypy|graalpy)(\d(\.?\d\d?)?t?)?\.exe$`)
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package pythonfinder
+
+// systemRegistry returns nil as the registry only exists on Windows.
+func systemRegistry() registryReader {
+	return nil
+}
@@ -0,0 +1,46 @@
+package pythonfinder
+
+import "golang.org/x/sys/windows/registry"
+
+// systemRegistry returns the registryReader for the Windows registry.
+func systemRegistry() registryReader {
+	return windowsRegistry{}
+}
+
+// windowsRegistry is the registryReader for the Windows registry. The errors
+// for the missing keys and values wrap fs.ErrNotExist.
+type windowsRegistry struct{}
+
+func (windowsRegistry) subKeyNames(key registryKey) ([]string, error) {
+	k, err := registry.OpenKey(windowsRoot(key.root), key.path, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil, err
+	}
+	defer k.Close()
+	return k.ReadSubKeyNames(-1)
+}
+
+func (windowsRegistry) stringValue(key registryKey, name string) (string, error) {
+	k, err := registry.OpenKey(windowsRoot(key.root), key.path, registry.QUERY_VALUE)
+	if err != nil {
+		return "", err
+	}
+	defer k.Close()
+
+	value, valueType, err := k.GetStringValue(name)
+	if err != nil {
+		return "", err
+	}
+	if valueType == registry.EXPAND_SZ {
+		return registry.ExpandString(value)
+	}
+	return value, nil
+}
+
+// windowsRoot returns the predefined Windows registry key for the given root.
+func windowsRoot(root registryRoot) registry.Key {
+	if root == hkeyCurrentUser {
+		return registry.CURRENT_USER
+	}
+	return registry.LOCAL_MACHINE
+}
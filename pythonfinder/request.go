@@ -0,0 +1,188 @@
+package pythonfinder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	pep440Version "github.com/aquasecurity/go-pep440-version"
+)
+
+// specifierOperators are the characters which start a PEP 440 version
+// specifier clause, e.g., ">=3.10" or "~=3.11".
+const specifierOperators = "<>=!~"
+
+// implementationRegex is a regular expression that matches the Python
+// implementation prefix of a version request, optionally separated from the
+// version by "@", e.g., "pypy3.10", "cpython@3.12" or "graalpy". The "python"
+// prefix matches any implementation.
+var implementationRegex = regexp.MustCompile(`^(?i)(cpython|pypy|graalpy|python)@?`)
+
+// versionRequest is a parsed Python version request as given by the user.
+type versionRequest struct {
+	// strategy is the strategy used to find the Python executable.
+	strategy finderStrategy
+
+	// implementation is the requested Python implementation in lowercase,
+	// e.g., "cpython" or "pypy". This is empty if any implementation is
+	// acceptable.
+	implementation string
+
+	// version is the requested version. This is nil for the findFirst,
+	// findAll and findSpecifier strategies.
+	version *pep440Version.Version
+
+	// specifier is used to match the versions for the findGlob and
+	// findSpecifier strategies.
+	specifier *pep440Version.Specifiers
+
+	// freeThreaded is true if a free-threaded build is requested using the
+	// "t" suffix, e.g., "3.13t". Otherwise, the free-threaded builds are not
+	// considered.
+	freeThreaded bool
+
+	// allowPrereleases is true if the pre-releases can match the findGlob
+	// and findSpecifier strategies. The caller should prefer a final release
+	// if any matches.
+	allowPrereleases bool
+}
+
+// parseVersionRequest parses the given version request which is an optional
+// implementation name, e.g., "cpython", "pypy" or "graalpy", followed by an
+// optional version, separated by an optional "@". For example, "3.11",
+// "pypy3.10", "cpython@>=3.12" or "graalpy". The implementation name "python"
+// matches any implementation.
+//
+// A version can have the "t" suffix, e.g., "3.13t", to request a free-threaded
+// build, similar to how the free-threaded executables are named.
+//
+// The strategy to find the Python executable is decided as per the version
+// using the following rules:
+//  1. If the given version is empty, find the first Python version.
+//  2. If the given version is a PEP 440 version specifier, e.g., ">=3.10,<3.13"
+//     or "~=3.11", find the preferred version among all the Python versions
+//     which satisfy the specifier.
+//  3. If the given version is a final release and not a complete version, find
+//     the preferred version among all the Python versions which match it. For
+//     example, if the given version is 3.11, match 3.11.*.
+//  4. Otherwise, find the Python version which matches the given version exactly.
+func parseVersionRequest(version string) (*versionRequest, error) {
+	request := &versionRequest{}
+
+	version = strings.TrimSpace(version)
+	if prefix := implementationRegex.FindStringSubmatch(version); prefix != nil {
+		if implementation := strings.ToLower(prefix[1]); implementation != "python" {
+			request.implementation = implementation
+		}
+		version = version[len(prefix[0]):]
+	}
+
+	if version == "" {
+		request.strategy = findFirst
+		return request, nil
+	}
+
+	if n := len(version); n > 1 && version[n-1] == 't' && isDigit(version[n-2]) {
+		request.freeThreaded = true
+		version = version[:n-1]
+	}
+
+	if isSpecifier(version) {
+		specifier, err := pep440Version.NewSpecifiers(version)
+		if err != nil {
+			return nil, err
+		}
+		request.strategy = findSpecifier
+		request.specifier = &specifier
+		return request, nil
+	}
+
+	v, err := pep440Version.Parse(version)
+	if err != nil {
+		return nil, err
+	}
+	request.version = &v
+	if !isFinalRelease(&v) || isCompleteVersion(&v) {
+		request.strategy = findExact
+		return request, nil
+	}
+
+	specifier, err := pep440Version.NewSpecifiers("== " + getGlobVersion(&v))
+	if err != nil {
+		return nil, err
+	}
+	request.strategy = findGlob
+	request.specifier = &specifier
+	return request, nil
+}
+
+// isSpecifier returns true if the given version looks like a PEP 440 version
+// specifier instead of a plain version.
+func isSpecifier(version string) bool {
+	return strings.ContainsAny(version[:1], specifierOperators) || strings.Contains(version, ",")
+}
+
+// isDigit returns true if the given byte is an ASCII digit.
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// rank returns the rank of the given Python executable which satisfies the
+// request, where the lowest rank is preferred. When no implementation is
+// requested, CPython is preferred over the other implementations, which would
+// otherwise be found first, e.g., "/usr/bin/pypy3" before "/usr/bin/python3".
+// Then, a final release is preferred over a pre-release for the requests
+// matching multiple versions.
+func (r *versionRequest) rank(pythonExecutable *PythonExecutable) int {
+	var rank int
+	if r.implementation == "" && pythonExecutable.Implementation != "" && pythonExecutable.Implementation != "cpython" {
+		rank += 2
+	}
+	if (r.strategy == findGlob || r.strategy == findSpecifier) && !isFinalRelease(pythonExecutable.Version) {
+		rank++
+	}
+	return rank
+}
+
+// numRanks is the number of ranks returned by versionRequest.rank.
+const numRanks = 4
+
+// matches returns true if the given Python executable satisfies the request.
+func (r *versionRequest) matches(pythonExecutable *PythonExecutable) bool {
+	return r.mismatch(pythonExecutable) == ""
+}
+
+// mismatch returns the reason why the given Python executable does not
+// satisfy the request, or an empty string if it does.
+func (r *versionRequest) mismatch(pythonExecutable *PythonExecutable) string {
+	if r.implementation != "" && r.implementation != pythonExecutable.Implementation {
+		return fmt.Sprintf("implementation %s is not %s", pythonExecutable.Implementation, r.implementation)
+	}
+	if r.freeThreaded != pythonExecutable.FreeThreaded {
+		if r.freeThreaded {
+			return "not a free-threaded build"
+		}
+		return "free-threaded build was not requested"
+	}
+	switch r.strategy {
+	case findExact:
+		if !pythonExecutable.Version.Equal(*r.version) {
+			return fmt.Sprintf("version %s is not %s", pythonExecutable.Version, r.version)
+		}
+	case findGlob:
+		if !r.allowPrereleases && !isFinalRelease(pythonExecutable.Version) {
+			return fmt.Sprintf("version %s is not a final release", pythonExecutable.Version)
+		}
+		if !r.specifier.Check(*pythonExecutable.Version) {
+			return fmt.Sprintf("version %s does not match %s", pythonExecutable.Version, getGlobVersion(r.version))
+		}
+	case findSpecifier:
+		if !r.allowPrereleases && !isFinalRelease(pythonExecutable.Version) {
+			return fmt.Sprintf("version %s is not a final release", pythonExecutable.Version)
+		}
+		if !r.specifier.Check(*pythonExecutable.Version) {
+			return fmt.Sprintf("version %s does not satisfy %s", pythonExecutable.Version, r.specifier)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package pythonfinder
+
+import "testing"
+
+func TestParseVersionRequest(t *testing.T) {
+	tests := []struct {
+		version string
+		want    finderStrategy
+	}{
+		{version: "", want: findFirst},
+		{version: "3", want: findGlob},
+		{version: "3.11", want: findGlob},
+		{version: "3.11.2", want: findExact},
+		{version: "3.12.0b1", want: findExact},
+		{version: ">=3.10", want: findSpecifier},
+		{version: ">=3.10,<3.13", want: findSpecifier},
+		{version: "~=3.11", want: findSpecifier},
+		{version: "==3.11.*", want: findSpecifier},
+		{version: "!=3.11.0", want: findSpecifier},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := parseVersionRequest(tt.version)
+			if err != nil {
+				t.Fatalf("parseVersionRequest(%q) error = %v", tt.version, err)
+			}
+			if got.strategy != tt.want {
+				t.Errorf("parseVersionRequest(%q).strategy = %v, want %v", tt.version, got.strategy, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionRequestImplementation(t *testing.T) {
+	tests := []struct {
+		version        string
+		implementation string
+		strategy       finderStrategy
+	}{
+		{version: "pypy", implementation: "pypy", strategy: findFirst},
+		{version: "PyPy3.10", implementation: "pypy", strategy: findGlob},
+		{version: "graalpy", implementation: "graalpy", strategy: findFirst},
+		{version: "cpython@3.12", implementation: "cpython", strategy: findGlob},
+		{version: "cpython@3.12.1", implementation: "cpython", strategy: findExact},
+		{version: "pypy@>=3.9", implementation: "pypy", strategy: findSpecifier},
+		{version: "python3.11", implementation: "", strategy: findGlob},
+		{version: "python", implementation: "", strategy: findFirst},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := parseVersionRequest(tt.version)
+			if err != nil {
+				t.Fatalf("parseVersionRequest(%q) error = %v", tt.version, err)
+			}
+			if got.implementation != tt.implementation || got.strategy != tt.strategy {
+				t.Errorf("parseVersionRequest(%q) = (%q, %v), want (%q, %v)",
+					tt.version, got.implementation, got.strategy, tt.implementation, tt.strategy)
+			}
+		})
+	}
+}
+
+func TestParseVersionRequestFreeThreaded(t *testing.T) {
+	tests := []struct {
+		version      string
+		freeThreaded bool
+		strategy     finderStrategy
+	}{
+		{version: "3.13t", freeThreaded: true, strategy: findGlob},
+		{version: "3.13.1t", freeThreaded: true, strategy: findExact},
+		{version: "python3.13t", freeThreaded: true, strategy: findGlob},
+		{version: "3.13", freeThreaded: false, strategy: findGlob},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := parseVersionRequest(tt.version)
+			if err != nil {
+				t.Fatalf("parseVersionRequest(%q) error = %v", tt.version, err)
+			}
+			if got.freeThreaded != tt.freeThreaded || got.strategy != tt.strategy {
+				t.Errorf("parseVersionRequest(%q) = (%v, %v), want (%v, %v)",
+					tt.version, got.freeThreaded, got.strategy, tt.freeThreaded, tt.strategy)
+			}
+		})
+	}
+}
+
+func TestParseVersionRequestInvalid(t *testing.T) {
+	for _, version := range []string{"three", ">=three", "jython3.11"} {
+		t.Run(version, func(t *testing.T) {
+			if _, err := parseVersionRequest(version); err == nil {
+				t.Errorf("parseVersionRequest(%q) error = nil, want non-nil", version)
+			}
+		})
+	}
+}
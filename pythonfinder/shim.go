@@ -0,0 +1,118 @@
+package pythonfinder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dhruvmanila/pie/internal/pathutil"
+)
+
+// shimManager is a version manager which puts a directory of shims in PATH,
+// e.g., "~/.pyenv/shims". A shim is a script which dispatches to the Python
+// executable selected by the version manager for the current directory, so
+// the shims themselves are not stable executables.
+type shimManager struct {
+	// name is the name of the version manager executable.
+	name string
+
+	// root returns the directory containing the "shims" directory.
+	root func() (string, error)
+
+	// prefixArgs are the arguments to the version manager executable which
+	// print the installation prefixes of the Python versions selected for
+	// the current directory, separated by the path list separator.
+	prefixArgs []string
+}
+
+// shimManagers are the version managers whose shims are resolved to the
+// Python executables they dispatch to.
+var shimManagers = []shimManager{
+	{name: "pyenv", root: pyenvRoot, prefixArgs: []string{"prefix"}},
+	{name: "asdf", root: asdfRoot, prefixArgs: []string{"where", "python"}},
+	{
+		name:       "mise",
+		root:       func() (string, error) { return miseRoot("mise") },
+		prefixArgs: []string{"where", "python"},
+	},
+}
+
+// shimDir is the shims directory of a version manager.
+type shimDir struct {
+	manager shimManager
+
+	// executable is the path to the version manager executable, or empty if
+	// it could not be found, in which case the shims cannot be resolved.
+	executable string
+}
+
+// shimDirs returns the existing shims directories of the version managers.
+func shimDirs() map[string]shimDir {
+	dirs := make(map[string]shimDir)
+	for _, manager := range shimManagers {
+		root, err := manager.root()
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join(root, "shims")
+		if !pathutil.IsDir(dir) {
+			continue
+		}
+		executable, err := exec.LookPath(manager.name)
+		if err != nil {
+			// The version manager might not be in PATH, e.g., when only the
+			// shims directory was added to it.
+			executable = filepath.Join(root, "bin", manager.name)
+			if _, err := exec.LookPath(executable); err != nil {
+				executable = ""
+			}
+		}
+		dirs[filepath.Clean(dir)] = shimDir{manager: manager, executable: executable}
+	}
+	return dirs
+}
+
+// prefixes returns the installation prefixes of the Python versions selected
+// by the version manager for the current directory, in the order of
+// preference. The version manager is only executed once, instead of once per
+// shim, as it can take a significant amount of time.
+func (d shimDir) prefixes(ctx context.Context) ([]string, error) {
+	if d.executable == "" {
+		return nil, fmt.Errorf("%s not found", d.manager.name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultProbeTimeout)
+	defer cancel()
+
+	output, err := execCommandContext(ctx, d.executable, d.manager.prefixArgs...).Output()
+	if err != nil {
+		return nil, err
+	}
+	var prefixes []string
+	for _, prefix := range filepath.SplitList(strings.TrimSpace(string(output))) {
+		if !filepath.IsAbs(prefix) {
+			return nil, fmt.Errorf("unexpected output from %s: %q", d.manager.name, output)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// resolveShim returns the path to the Python executable the given shim
+// dispatches to, which is the executable with the same name in the first of
+// the given installation prefixes containing one.
+//
+// This fails if the shim does not dispatch to any installed Python version,
+// e.g., the "python2" shim when the selected version is a Python 3 version.
+func resolveShim(prefixes []string, shim string) (string, bool) {
+	for _, prefix := range prefixes {
+		executable := filepath.Join(prefix, binDir, filepath.Base(shim))
+		if info, err := os.Stat(executable); err == nil && isExecutable(info) {
+			return executable, true
+		}
+	}
+	return "", false
+}
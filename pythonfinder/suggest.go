@@ -0,0 +1,166 @@
+package pythonfinder
+
+import (
+	"strconv"
+	"strings"
+
+	pep440Version "github.com/aquasecurity/go-pep440-version"
+)
+
+// Suggest returns the Python installations closest to the given version which
+// could not be found, among the given ones, e.g., as returned by FindAll.
+//
+// The suggestions are, in order, the closest lower and higher versions to the
+// requested one, followed by the newest version overall. Only the newest patch
+// version is suggested for the minor versions other than the requested one. The installations are restricted to the
+// requested implementation and free-threaded builds, if any match.
+//
+// It returns nil if the version is not a valid version request.
+func Suggest(version string, installations []*Installation) []*Installation {
+	request, err := parseVersionRequest(version)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []*Installation
+	for _, installation := range installations {
+		if request.implementation != "" && request.implementation != installation.Implementation {
+			continue
+		}
+		if request.freeThreaded != installation.FreeThreaded {
+			continue
+		}
+		candidates = append(candidates, installation)
+	}
+	if len(candidates) == 0 {
+		candidates = installations
+	}
+
+	var closest []*Installation
+	if request.version != nil {
+		lower, higher := closestVersions(request.version, candidates)
+		for _, installation := range []*Installation{lower, higher} {
+			if installation != nil {
+				closest = append(closest, installation)
+			}
+		}
+	}
+
+	// Only the newest installation of each minor version is suggested, for
+	// each implementation and kind of build.
+	var newest *Installation
+	newestByMinor := make(map[minorKey]*Installation)
+	for _, installation := range candidates {
+		if newest == nil || installation.Version.GreaterThan(*newest.Version) {
+			newest = installation
+		}
+		minor := newMinorKey(installation)
+		if current, ok := newestByMinor[minor]; !ok || installation.Version.GreaterThan(*current.Version) {
+			newestByMinor[minor] = installation
+		}
+	}
+	if newest != nil {
+		closest = append(closest, newest)
+	}
+
+	var suggestions []*Installation
+	seen := make(map[*Installation]bool)
+	for _, installation := range closest {
+		// The nearest patch versions of the requested minor version are
+		// suggested as is, e.g., 3.11.4 and 3.11.9 for 3.11.5.
+		if !request.sameMinor(installation.Version) {
+			installation = newestByMinor[newMinorKey(installation)]
+		}
+		if !seen[installation] {
+			seen[installation] = true
+			suggestions = append(suggestions, installation)
+		}
+	}
+	return suggestions
+}
+
+// sameMinor returns true if the given version has the same major and minor
+// version as the requested one.
+func (r *versionRequest) sameMinor(version *pep440Version.Version) bool {
+	if r.version == nil {
+		return false
+	}
+	want, got := releaseSegments(r.version), releaseSegments(version)
+	return want[0] == got[0] && want[1] == got[1]
+}
+
+// closestVersions returns the installations with the same major version as
+// the given version which are the closest to it, the lower or equal one and
+// the higher one, comparing the minor version first and then the patch
+// version. Either is nil if there is no such installation.
+func closestVersions(version *pep440Version.Version, installations []*Installation) (lower, higher *Installation) {
+	want := releaseSegments(version)
+
+	var lowerSegments, higherSegments [3]int
+	for _, installation := range installations {
+		got := releaseSegments(installation.Version)
+		if got[0] != want[0] {
+			continue
+		}
+		if !segmentsLess(want, got) {
+			if lower == nil || segmentsLess(lowerSegments, got) ||
+				(got == lowerSegments && installation.Version.GreaterThan(*lower.Version)) {
+				lower, lowerSegments = installation, got
+			}
+		} else {
+			if higher == nil || segmentsLess(got, higherSegments) ||
+				(got == higherSegments && installation.Version.GreaterThan(*higher.Version)) {
+				higher, higherSegments = installation, got
+			}
+		}
+	}
+	return lower, higher
+}
+
+// segmentsLess returns true if the release segments a are lower than b.
+func segmentsLess(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// releaseSegments returns the major, minor and patch components of the given
+// version, where the missing ones are zero.
+func releaseSegments(version *pep440Version.Version) [3]int {
+	var segments [3]int
+	// The base version does not include the pre-release, post-release or
+	// local parts, but can include the epoch, e.g., "1!3.11.4".
+	base := version.BaseVersion()
+	if i := strings.Index(base, "!"); i >= 0 {
+		base = base[i+1:]
+	}
+	for i, part := range strings.SplitN(base, ".", 4) {
+		if i == len(segments) {
+			break
+		}
+		segments[i], _ = strconv.Atoi(part)
+	}
+	return segments
+}
+
+// minorKey identifies the minor version of an installation, e.g., CPython
+// 3.12, for the suggestions.
+type minorKey struct {
+	implementation string
+	freeThreaded   bool
+	major, minor   int
+}
+
+// newMinorKey returns the minorKey of the given installation.
+func newMinorKey(installation *Installation) minorKey {
+	segments := releaseSegments(installation.Version)
+	return minorKey{
+		implementation: installation.Implementation,
+		freeThreaded:   installation.FreeThreaded,
+		major:          segments[0],
+		minor:          segments[1],
+	}
+}
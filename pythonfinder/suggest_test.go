@@ -0,0 +1,74 @@
+package pythonfinder
+
+import (
+	"reflect"
+	"testing"
+
+	pep440Version "github.com/aquasecurity/go-pep440-version"
+)
+
+// newInstallation returns an Installation for the given version of the
+// given implementation.
+func newInstallation(implementation, version string) *Installation {
+	v := pep440Version.MustParse(version)
+	return &Installation{
+		PythonExecutable: &PythonExecutable{
+			Version:        &v,
+			Path:           fakePython(version),
+			Implementation: implementation,
+		},
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	installations := []*Installation{
+		newInstallation("cpython", "3.10.4"),
+		newInstallation("cpython", "3.11.2"),
+		newInstallation("cpython", "3.11.8"),
+		newInstallation("cpython", "3.13.1"),
+		newInstallation("pypy", "3.10.14"),
+	}
+
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		// The nearest patch versions of the same minor version.
+		{version: "3.11.9", want: []string{"3.11.8", "3.13.1"}},
+		{version: "3.11.3", want: []string{"3.11.2", "3.11.8", "3.13.1"}},
+		// The nearest minor versions on both sides.
+		{version: "3.12", want: []string{"3.11.8", "3.13.1"}},
+		{version: "3.12.5", want: []string{"3.11.8", "3.13.1"}},
+		{version: "3.9", want: []string{"3.10.4", "3.13.1"}},
+		{version: "pypy3.9", want: []string{"3.10.14"}},
+		{version: ">=3.14", want: []string{"3.13.1"}},
+		{version: "3.14t", want: []string{"3.13.1"}},
+		{version: "2.7", want: []string{"3.13.1"}},
+		{version: "not-a-version", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			var got []string
+			for _, installation := range Suggest(tt.version, installations) {
+				got = append(got, installation.Version.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+
+	// Only the patch versions of the requested minor version are installed.
+	installations = []*Installation{
+		newInstallation("cpython", "3.11.4"),
+		newInstallation("cpython", "3.11.9"),
+	}
+	var got []string
+	for _, installation := range Suggest("3.11.5", installations) {
+		got = append(got, installation.Version.String())
+	}
+	if want := []string{"3.11.4", "3.11.9"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Suggest(%q) = %v, want %v", "3.11.5", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package pythonfinder
+
+// TraceKind is the kind of a TraceEvent.
+type TraceKind int
+
+const (
+	// TraceProvider is emitted when a provider is consulted.
+	TraceProvider TraceKind = iota
+
+	// TraceCandidate is emitted for each executable found by a provider.
+	TraceCandidate
+
+	// TraceProbed is emitted when a candidate was probed successfully.
+	TraceProbed
+
+	// TraceRejected is emitted when a candidate is rejected, with the
+	// reason for it.
+	TraceRejected
+
+	// TraceMatched is emitted when a candidate matches the requested
+	// version, but the finder continues to look for the preferred one.
+	TraceMatched
+
+	// TraceSelected is emitted for the candidate chosen by the finder.
+	TraceSelected
+)
+
+func (k TraceKind) String() string {
+	switch k {
+	case TraceProvider:
+		return "provider"
+	case TraceCandidate:
+		return "candidate"
+	case TraceProbed:
+		return "probed"
+	case TraceRejected:
+		return "rejected"
+	case TraceMatched:
+		return "matched"
+	case TraceSelected:
+		return "selected"
+	default:
+		return "unknown"
+	}
+}
+
+// TraceEvent describes a single decision made by the finder.
+type TraceEvent struct {
+	Kind TraceKind
+
+	// Provider is the name of the provider which found the candidate, or
+	// the one being consulted for TraceProvider.
+	Provider string
+
+	// Path is the path to the candidate. This is empty for TraceProvider.
+	Path string
+
+	// PythonExecutable is the probed information about the candidate. This
+	// is nil if the candidate was not probed.
+	PythonExecutable *PythonExecutable
+
+	// Reason explains why the candidate was rejected, for TraceRejected.
+	Reason string
+}
+
+// Tracer is a function which is called with every TraceEvent while finding
+// the Python executables. The events are emitted in order, from the
+// goroutine which called the finder.
+type Tracer func(event TraceEvent)
+
+// trace calls the tracer of the finder, if any, with the given event.
+func (f *Finder) trace(event TraceEvent) {
+	if f.tracer != nil {
+		f.tracer(event)
+	}
+}
@@ -2,15 +2,12 @@ package pythonfinder
 
 import (
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
 
 	pep440Version "github.com/aquasecurity/go-pep440-version"
 )
 
-var execCommand = exec.Command
-
 // PythonExecutable contains information about a Python executable.
 type PythonExecutable struct {
 	// Version is the parsed Python version.
@@ -18,45 +15,49 @@ type PythonExecutable struct {
 
 	// Path is the absolute path to the Python executable.
 	Path string
-}
 
-// newPythonExecutable creates a new PythonExecutable from the given Python
-// executable path.
-func newPythonExecutable(executable string) (*PythonExecutable, error) {
-	versionInfo, err := getPythonVersion(executable)
-	if err != nil {
-		return nil, err
-	}
-	return &PythonExecutable{Version: versionInfo, Path: executable}, nil
-}
+	// Implementation is the Python implementation in lowercase, e.g.,
+	// "cpython", "pypy" or "graalpy".
+	Implementation string
 
-func (v *PythonExecutable) String() string {
-	return fmt.Sprintf("%s (%s)", v.Version, v.Path)
-}
+	// Arch is the machine architecture the Python executable was built for,
+	// e.g., "x86_64" or "aarch64".
+	Arch string
 
-// getPythonVersion returns the version information for the given Python
-// executable.
-func getPythonVersion(executable string) (*pep440Version.Version, error) {
-	cmd := execCommand(executable, "--version")
+	// PointerSize is the size of a pointer in bits, which is 32 for a 32-bit
+	// build and 64 for a 64-bit build.
+	PointerSize int
 
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
-	}
+	// BasePrefix is the installation prefix of the base Python, i.e., the
+	// value of `sys.base_prefix`.
+	BasePrefix string
 
-	// Output: "Python <version><LF/CRLF>"
-	_, version, found := strings.Cut(string(output), " ")
-	if !found {
-		return nil, fmt.Errorf("Unable to parse Python version: %q", output)
-	}
-	version = strings.TrimRight(version, "\r\n")
+	// IsVirtualenv is true if the Python executable belongs to a virtual
+	// environment.
+	IsVirtualenv bool
 
-	versionInfo, err := pep440Version.Parse(version)
-	if err != nil {
-		return nil, err
-	}
+	// ABIFlags are the ABI flags the Python executable was built with, i.e.,
+	// the value of `sys.abiflags`.
+	ABIFlags string
+
+	// FreeThreaded is true if the Python executable is a free-threaded build,
+	// i.e., built with the GIL disabled.
+	FreeThreaded bool
+
+	// HasVenv is true if the "venv" module can be imported.
+	HasVenv bool
 
-	return &versionInfo, nil
+	// HasEnsurepip is true if the "ensurepip" module can be imported, which
+	// is required to install pip in the virtual environment.
+	HasEnsurepip bool
+
+	// Registry is the registration of the Python installation in the
+	// Windows registry, or nil if it's not registered.
+	Registry *RegistryInfo
+}
+
+func (v *PythonExecutable) String() string {
+	return fmt.Sprintf("%s (%s)", v.Version, v.Path)
 }
 
 // isFinalRelease returns true if the given version is a final release, i.e.,
@@ -65,6 +66,11 @@ func isFinalRelease(version *pep440Version.Version) bool {
 	return !version.IsPreRelease() && !version.IsPostRelease() && version.Local() == ""
 }
 
+// minimumVersion is the lowest Python version supported by the finder, as
+// the virtual environments are created using the venv module which is not
+// available in Python 2.
+var minimumVersion = pep440Version.MustParse("3.0.dev0")
+
 // versionRegex is a regular expression that matches Python version strings
 // of the form "X.Y.Z".
 var versionRegex = regexp.MustCompile(`^(\d)\.(\d{1,2})\.(\d{1,2})$`)
@@ -0,0 +1,119 @@
+package pythonfinder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrNoVenv is returned by CanCreateVirtualenv when the "venv" module is
+	// not available.
+	ErrNoVenv = errors.New("the venv module is not available")
+
+	// ErrNoEnsurepip is returned by CanCreateVirtualenv when the "ensurepip"
+	// module is not available, e.g., for the system Python on Debian and
+	// Ubuntu unless the "python3-venv" package is installed.
+	ErrNoEnsurepip = errors.New("the ensurepip module is not available")
+)
+
+// CanCreateVirtualenv is a Filter which only accepts the Python executables
+// that can create a virtual environment with pip installed in it, using the
+// "venv" module.
+func CanCreateVirtualenv(pythonExecutable *PythonExecutable) error {
+	if !pythonExecutable.HasVenv {
+		return ErrNoVenv
+	}
+	if !pythonExecutable.HasEnsurepip {
+		return ErrNoEnsurepip
+	}
+	return nil
+}
+
+// pyvenvConfigName is the name of the configuration file of a virtual
+// environment as per PEP 405.
+const pyvenvConfigName = "pyvenv.cfg"
+
+// findPyvenvConfig returns the path to the configuration file of the virtual
+// environment the given Python executable belongs to, or an empty string if
+// it's not inside a virtual environment.
+//
+// As per PEP 405, the configuration file is either in the same directory as
+// the executable or in its parent directory.
+func findPyvenvConfig(executable string) string {
+	dir := filepath.Dir(executable)
+	for _, path := range []string{
+		filepath.Join(dir, pyvenvConfigName),
+		filepath.Join(filepath.Dir(dir), pyvenvConfigName),
+	} {
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+	}
+	return ""
+}
+
+// readPyvenvConfig reads the "key = value" pairs from the given virtual
+// environment configuration file. The keys are in lowercase.
+func readPyvenvConfig(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		config[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return config, scanner.Err()
+}
+
+// baseExecutable returns the absolute, resolved path to the base Python
+// executable of the virtual environment with the given configuration file,
+// which the given virtual environment executable points to.
+//
+// The "home" key in the configuration file is the directory containing the
+// base executable. An executable with the same name is looked up first, and
+// then the ones named after the version of the virtual environment, as the
+// base installation might not provide an unversioned "python" executable.
+func baseExecutable(executable, configPath string) (string, error) {
+	config, err := readPyvenvConfig(configPath)
+	if err != nil {
+		return "", err
+	}
+	home := config["home"]
+	if home == "" {
+		return "", fmt.Errorf("%s: missing the \"home\" key", configPath)
+	}
+
+	names := []string{filepath.Base(executable)}
+	version := config["version"]
+	if version == "" {
+		// This is used by uv and virtualenv instead of "version".
+		version = config["version_info"]
+	}
+	if parts := strings.Split(version, "."); len(parts) >= 2 {
+		ext := filepath.Ext(executable)
+		names = append(names, "python"+parts[0]+"."+parts[1]+ext, "python"+parts[0]+ext)
+	}
+
+	for _, name := range names {
+		path, err := filepath.EvalSymlinks(filepath.Join(home, name))
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && isExecutable(info) {
+			return filepath.Abs(path)
+		}
+	}
+	return "", fmt.Errorf("base Python executable not found in %s", home)
+}
@@ -0,0 +1,168 @@
+package pythonfinder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFakeVirtualenv creates a fake virtual environment in the given
+// directory with the given configuration, and returns the path to its Python
+// executable.
+func writeFakeVirtualenv(t *testing.T, dir, config string) string {
+	t.Helper()
+	executable := writeFakePython(t, filepath.Join(dir, binDir))
+	path := filepath.Join(dir, pyvenvConfigName)
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatalf("WriteFile(%q) error = %v", path, err)
+	}
+	return executable
+}
+
+func TestFindPyvenvConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		executable string
+		want       bool
+	}{
+		{
+			name:       "above",
+			executable: writeFakeVirtualenv(t, filepath.Join(dir, "above"), ""),
+			want:       true,
+		},
+		{
+			name: "beside",
+			executable: func() string {
+				executable := writeFakePython(t, filepath.Join(dir, "beside", "bin"))
+				writeFakeVirtualenv(t, filepath.Dir(executable), "")
+				return executable
+			}(),
+			want: true,
+		},
+		{
+			name:       "none",
+			executable: writeFakePython(t, filepath.Join(dir, "none", "bin")),
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findPyvenvConfig(tt.executable)
+			if (got != "") != tt.want {
+				t.Errorf("findPyvenvConfig(%q) = %q, want found %v", tt.executable, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseExecutable(t *testing.T) {
+	dir := t.TempDir()
+	home := filepath.Dir(resolvePath(t, writeFakePython(t, filepath.Join(dir, "base", binDir))))
+	versioned := filepath.Join(home, "python3.11"+filepath.Ext(pythonExeName))
+	if err := os.WriteFile(versioned, nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "same name",
+			config: "home = " + home + "\n",
+			want:   filepath.Join(home, pythonExeName),
+		},
+		{
+			name:   "version",
+			config: "home = " + home + "\nversion = 3.11.4\n",
+			want:   filepath.Join(home, pythonExeName),
+		},
+		{
+			name:    "missing home",
+			config:  "version = 3.11.4\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing base",
+			config:  "home = " + filepath.Join(dir, "missing") + "\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venv := filepath.Join(t.TempDir(), "venv")
+			executable := writeFakeVirtualenv(t, venv, tt.config)
+			got, err := baseExecutable(executable, filepath.Join(venv, pyvenvConfigName))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("baseExecutable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("baseExecutable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("versioned name", func(t *testing.T) {
+		venv := filepath.Join(t.TempDir(), "venv")
+		executable := writeFakeVirtualenv(t, venv, "home = "+home+"\nversion_info = 3.11.4.final.0\n")
+		renamed := filepath.Join(filepath.Dir(executable), "python3"+filepath.Ext(pythonExeName))
+		if err := os.Rename(executable, renamed); err != nil {
+			t.Fatal(err)
+		}
+		got, err := baseExecutable(renamed, filepath.Join(venv, pyvenvConfigName))
+		if err != nil {
+			t.Fatalf("baseExecutable() error = %v", err)
+		}
+		if got != versioned {
+			t.Errorf("baseExecutable() = %q, want %q", got, versioned)
+		}
+	})
+}
+
+func TestCandidatesVirtualenv(t *testing.T) {
+	dir := t.TempDir()
+	base := resolvePath(t, writeFakePython(t, filepath.Join(dir, "base", binDir)))
+	venv := writeFakeVirtualenv(t, filepath.Join(dir, "venv"), "home = "+filepath.Dir(base)+"\n")
+	other := resolvePath(t, writeFakePython(t, filepath.Join(dir, "other", binDir)))
+
+	tests := []struct {
+		name           string
+		virtualenvBase bool
+		want           []string
+	}{
+		{
+			name: "skip",
+			want: []string{other, base},
+		},
+		{
+			name:           "base",
+			virtualenvBase: true,
+			want:           []string{base, other},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Finder{
+				providers:      []Provider{fakeProvider{venv, other, base}},
+				virtualenvBase: tt.virtualenvBase,
+			}
+			candidates := f.candidates(context.Background())
+			var got []string
+			for _, c := range candidates {
+				got = append(got, c.path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("candidates() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}